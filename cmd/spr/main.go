@@ -1,171 +1,115 @@
 package main
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/schollz/progressbar/v3"
-)
-
-type Parameter struct {
-	Name     string                 `json:"name"`
-	In       string                 `json:"in"`
-	Required bool                   `json:"required"`
-	Schema   map[string]interface{} `json:"schema"`
-	Example  interface{}            `json:"example"`
-}
-
-type Operation struct {
-	Parameters  []Parameter            `json:"parameters"`
-	RequestBody map[string]interface{} `json:"requestBody"`
-}
-
-type PathItem struct {
-	Get        *Operation  `json:"get"`
-	Post       *Operation  `json:"post"`
-	Put        *Operation  `json:"put"`
-	Delete     *Operation  `json:"delete"`
-	Patch      *Operation  `json:"patch"`
-	Parameters []Parameter `json:"parameters"`
-}
-
-type OpenAPI struct {
-	Paths map[string]PathItem `json:"paths"`
-}
-
-// Static UUID for all requests
-const staticUUID = "11111111-1111-1111-1111-111111111111"
-
-// Integer fuzzing values
-var intFuzzValues = []int{1, 10, 100, 1000, 10000, 100000, 1000000, 10000000,
-	2, 20, 200, 2000, 20000, 200000, 2000000, 20000000,
-	5, 50, 500, 5000, 50000, 500000, 5000000, 50000000,
-	9, 90, 900, 9000, 90000, 900000, 9000000, 90000000}
-
-func getDummyValue(schema map[string]interface{}, example interface{}, intFuzz bool, fuzzValue int, paramName string, paramOverrides map[string]string) interface{} {
-	// Check if there's an override for this parameter
-	if override, exists := paramOverrides[paramName]; exists && !intFuzz {
-		return override
-	}
-
-	if example != nil {
-		return example
-	}
-
-	schemaType, _ := schema["type"].(string)
-	schemaFormat, _ := schema["format"].(string)
-
-	// Check for UUID format first
-	if schemaFormat == "uuid" {
-		return staticUUID
-	}
-
-	// If type is missing but properties exist, treat as object
-	if schemaType == "" {
-		if properties, ok := schema["properties"].(map[string]interface{}); ok {
-			result := make(map[string]interface{})
-			for key, prop := range properties {
-				propMap := prop.(map[string]interface{})
-				result[key] = getDummyValue(propMap, nil, intFuzz, fuzzValue, key, paramOverrides)
-			}
-			return result
-		}
-	}
 
-	switch schemaType {
-	case "string":
-		return "test_string"
-	case "integer":
-		if intFuzz {
-			if override, exists := paramOverrides[paramName]; exists {
-				return override
-			}
-			return fuzzValue
-		}
-		return 1000
-	case "number":
-		return 1.0
-	case "boolean":
-		return true
-	case "array":
-		items := schema["items"].(map[string]interface{})
-		return []interface{}{getDummyValue(items, nil, intFuzz, fuzzValue, paramName, paramOverrides)}
-	case "object":
-		result := make(map[string]interface{})
-		if properties, ok := schema["properties"].(map[string]interface{}); ok {
-			for key, prop := range properties {
-				propMap := prop.(map[string]interface{})
-				result[key] = getDummyValue(propMap, nil, intFuzz, fuzzValue, key, paramOverrides)
-			}
-		}
-		return result
-	default:
-		// Return empty object instead of string if properties exist
-		if properties, ok := schema["properties"].(map[string]interface{}); ok {
-			result := make(map[string]interface{})
-			for key, prop := range properties {
-				propMap := prop.(map[string]interface{})
-				result[key] = getDummyValue(propMap, nil, intFuzz, fuzzValue, key, paramOverrides)
-			}
-			return result
-		}
-		return "test_value"
-	}
-}
+	"github.com/vrechson/SPR/internal/auth"
+	"github.com/vrechson/SPR/internal/fuzz"
+	"github.com/vrechson/SPR/internal/openapi"
+	"github.com/vrechson/SPR/internal/recorder"
+	"github.com/vrechson/SPR/internal/stateful"
+	"github.com/vrechson/SPR/internal/transport"
+)
 
 func normalizeHost(host string) string {
 	return strings.TrimRight(host, "/")
 }
 
-func hasIntegerParams(operation *Operation, pathParams []Parameter) bool {
-	allParams := append(pathParams, operation.Parameters...)
-
-	// Check path and query parameters
-	for _, param := range allParams {
-		if schemaType, ok := param.Schema["type"].(string); ok && schemaType == "integer" {
-			return true
-		}
-	}
-
-	// Check request body
-	if operation.RequestBody != nil {
-		if content, ok := operation.RequestBody["content"].(map[string]interface{}); ok {
-			if jsonContent, ok := content["application/json"]; ok {
-				if schema, ok := jsonContent.(map[string]interface{})["schema"].(map[string]interface{}); ok {
-					if schemaType, ok := schema["type"].(string); ok && schemaType == "integer" {
-						return true
-					}
-				}
-			}
-		}
-	}
-
-	return false
+// requestCase is a single concrete HTTP request produced by expanding a
+// path x method x strategy combination against one variant index.
+type requestCase struct {
+	bundle       recorder.Bundle
+	method       string
+	url          string
+	body         []byte
+	contentType  string
+	headerMap    map[string]string
+	authProvider auth.Provider
 }
 
 func main() {
-	swaggerFile := flag.String("swagger", "", "Path to OpenAPI/Swagger file")
-	host := flag.String("host", "", "Target host")
+	swaggerFile := flag.String("swagger", "", "Path to OpenAPI/Swagger file (JSON or YAML)")
+	host := flag.String("host", "", "Target host (defaults to the spec's first `servers` entry)")
 	proxy := flag.String("proxy", "http://127.0.0.1:8080", "Proxy URL")
 	methods := flag.String("methods", "GET", "Comma-separated list of HTTP methods to test (GET,POST,PUT,DELETE,PATCH)")
-	intFuzzing := flag.Bool("int-fuzzing", false, "Enable integer parameter fuzzing")
-	headers := flag.String("H", "", "Headers to add to requests (can be specified multiple times)")
+	intFuzzing := flag.Bool("int-fuzzing", false, "Enable integer parameter fuzzing under the default strategy")
+	var headers arrayFlags
+	flag.Var(&headers, "H", "Header to add to requests, in 'Key: value' form (can be specified multiple times)")
 	threads := flag.Int("threads", 10, "Number of concurrent threads")
 	verbose := flag.Bool("v", false, "Verbose output")
 	var paramOverrides arrayFlags
 	flag.Var(&paramOverrides, "param-override", "Override parameter values in format param=value (can be specified multiple times)")
+	var strategyNames arrayFlags
+	flag.Var(&strategyNames, "strategy", fmt.Sprintf("Fuzzing strategy to apply (repeatable, default: default). One of: %s", strings.Join(fuzz.Names(), ", ")))
+	reportPath := flag.String("report", "", "Write a findings report to this path (format inferred from -report-format)")
+	reportFormat := flag.String("report-format", "json", "Report format: json or html")
+	baselineHeaders := flag.String("H-baseline", "", "Headers for the differential baseline request (comma-separated key:value)")
+	candidateHeaders := flag.String("H-candidate", "", "Headers for the differential candidate request (comma-separated key:value)")
+	statefulMode := flag.Bool("stateful", false, "Sequence operations (create before read/update before delete) and thread captured response ids into later requests")
+	var chainSpecs arrayFlags
+	flag.Var(&chainSpecs, "chain", "Override stateful id inference: KEY->PATH{param} (can be specified multiple times)")
+	socks5Proxy := flag.String("tor", "", "SOCKS5 proxy address (e.g. 127.0.0.1:9050 for Tor); takes precedence over -proxy")
+	rps := flag.Float64("rps", 0, "Per-host requests/second rate limit (0 disables)")
+	burst := flag.Int("burst", 5, "Per-host rate limiter burst size")
+	retries := flag.Int("retries", 0, "Retry attempts for 429/5xx responses, honoring Retry-After (0 disables)")
+	maxBody := flag.Int64("max-body", 0, "Cap request/response body size in bytes (0 disables the cap)")
+	clientCert := flag.String("client-cert", "", "Client certificate path for mTLS")
+	clientKey := flag.String("client-key", "", "Client key path for mTLS")
+	useHTTP2 := flag.Bool("http2", false, "Enable HTTP/2 negotiation on TLS connections")
+	useHTTP3 := flag.Bool("http3", false, "Use HTTP/3 (QUIC) instead of TCP; incompatible with -proxy/-tor")
+	replayPath := flag.String("replay", "", "Replay requests from a prior -report JSON file instead of fuzzing")
+	replayStatuses := flag.String("replay-status", "", "Comma-separated status codes to replay (default: all)")
+	var authSpecs arrayFlags
+	flag.Var(&authSpecs, "auth", "Credential for a security scheme: bearer:$TOKEN | basic:user:pass | apikey:value | oauth2:token_url=...,client_id=...,client_secret=...,scope=... (can be specified multiple times)")
 	flag.Parse()
 
-	if *swaggerFile == "" || *host == "" {
-		fmt.Println("Please provide swagger file path and host")
+	// Parse headers up front since -replay also wants them.
+	headerMap := parseHeaderList(headers)
+
+	transportCfg := transport.Config{
+		ProxyURL:           *proxy,
+		SOCKS5Addr:         *socks5Proxy,
+		InsecureSkipVerify: true,
+		ClientCertPath:     *clientCert,
+		ClientKeyPath:      *clientKey,
+		HTTP2:              *useHTTP2,
+		HTTP3:              *useHTTP3,
+		RPS:                *rps,
+		Burst:              *burst,
+		MaxRetries:         *retries,
+		MaxBodyBytes:       *maxBody,
+	}
+	client, err := transport.New(transportCfg)
+	if err != nil {
+		fmt.Printf("Error configuring transport: %v\n", err)
+		os.Exit(1)
+	}
+
+	// authRegistry's oauth2 token fetches reuse this same client, so they
+	// go through the configured proxy/SOCKS5/mTLS too.
+	authRegistry, err := auth.NewRegistry(authSpecs, client)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if *replayPath != "" {
+		runReplay(client, *replayPath, *replayStatuses, headerMap, *reportPath, *reportFormat)
+		return
+	}
+
+	if *swaggerFile == "" {
+		fmt.Println("Please provide swagger file path")
 		os.Exit(1)
 	}
 
@@ -176,17 +120,6 @@ func main() {
 		methodsMap[strings.TrimSpace(m)] = true
 	}
 
-	// Parse headers
-	headerMap := make(map[string]string)
-	if *headers != "" {
-		for _, h := range strings.Split(*headers, ",") {
-			parts := strings.SplitN(h, ":", 2)
-			if len(parts) == 2 {
-				headerMap[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
-			}
-		}
-	}
-
 	// Parse param overrides
 	paramOverrideMap := make(map[string]string)
 	for _, override := range paramOverrides {
@@ -196,58 +129,100 @@ func main() {
 		}
 	}
 
-	// Read and parse swagger file
-	data, err := ioutil.ReadFile(*swaggerFile)
-	if err != nil {
-		fmt.Printf("Error reading swagger file: %v\n", err)
-		os.Exit(1)
-	}
+	// Differential mode: a request is sent once per header bundle, and the
+	// report flags endpoints whose responses don't actually differ.
+	diffMode := *baselineHeaders != "" || *candidateHeaders != ""
+	baselineHeaderMap := parseHeaderFlag(*baselineHeaders)
+	candidateHeaderMap := parseHeaderFlag(*candidateHeaders)
 
-	var api OpenAPI
-	if err := json.Unmarshal(data, &api); err != nil {
-		fmt.Printf("Error parsing swagger file: %v\n", err)
-		os.Exit(1)
+	// Resolve the selected strategies, defaulting to "default"
+	if len(strategyNames) == 0 {
+		strategyNames = arrayFlags{"default"}
+	}
+	strategies := make([]fuzz.Strategy, 0, len(strategyNames))
+	for _, name := range strategyNames {
+		s, ok := fuzz.Lookup(name)
+		if !ok {
+			fmt.Printf("Unknown -strategy %q (available: %s)\n", name, strings.Join(fuzz.Names(), ", "))
+			os.Exit(1)
+		}
+		strategies = append(strategies, s)
 	}
 
-	// Configure proxy and disable TLS verification
-	proxyURL, err := url.Parse(*proxy)
+	// Load and normalize the spec (JSON/YAML, Swagger 2.0/OpenAPI 3.x, $ref resolved)
+	api, err := openapi.Load(*swaggerFile)
 	if err != nil {
-		fmt.Printf("Error parsing proxy URL: %v\n", err)
+		fmt.Printf("Error loading swagger file: %v\n", err)
 		os.Exit(1)
 	}
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			Proxy: http.ProxyURL(proxyURL),
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
+	resolvedHost := *host
+	if resolvedHost == "" {
+		if len(api.Servers) == 0 {
+			fmt.Println("Please provide -host (the spec has no `servers` entry to default from)")
+			os.Exit(1)
+		}
+		resolvedHost = api.Servers[0].URL
 	}
 
-	normalizedHost := normalizeHost(*host)
-
-	// Count total requests to be made
-	totalRequests := 0
-	for _, pathItem := range api.Paths {
-		operations := map[string]*Operation{
-			"GET":    pathItem.Get,
-			"POST":   pathItem.Post,
-			"PUT":    pathItem.Put,
-			"DELETE": pathItem.Delete,
-			"PATCH":  pathItem.Patch,
+	normalizedHost := normalizeHost(resolvedHost)
+
+	var chains []stateful.ChainOverride
+	for _, spec := range chainSpecs {
+		chain, err := stateful.ParseChain(spec)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
+		chains = append(chains, chain)
+	}
+
+	if *statefulMode {
+		rec := recorder.New()
+		runStateful(client, api, authRegistry, methodsMap, strategies, *intFuzzing, paramOverrideMap, headerMap, chains, rec, normalizedHost, *verbose)
+		writeReportIfRequested(rec, *reportPath, *reportFormat)
+		return
+	}
 
-		for method, operation := range operations {
-			if operation == nil || !methodsMap[method] {
+	// Build every request case up front so the progress bar total is exact
+	// and the worker pool can stream them through a channel unchanged.
+	var cases []requestCase
+	for path, pathItem := range api.Paths {
+		for method, operation := range pathItem.Operations() {
+			if !methodsMap[method] {
 				continue
 			}
-
-			hasIntegers := hasIntegerParams(operation, pathItem.Parameters)
-			if *intFuzzing && hasIntegers {
-				totalRequests += len(intFuzzValues)
-			} else {
-				totalRequests++
+			authPlan := authRegistry.Resolve(operation, api)
+			for _, strategy := range strategies {
+				base := expandCases(normalizedHost, path, method, pathItem, operation, strategy, *intFuzzing, paramOverrideMap, headerMap, authPlan.Primary)
+				switch {
+				case diffMode:
+					for _, c := range base {
+						baseline := c
+						baseline.bundle = recorder.BundleBaseline
+						baseline.headerMap = baselineHeaderMap
+						candidate := c
+						candidate.bundle = recorder.BundleCandidate
+						candidate.headerMap = candidateHeaderMap
+						cases = append(cases, baseline, candidate)
+					}
+				case authPlan.Probe != nil:
+					// The operation explicitly opts out of auth
+					// (`security: []`), so exercise it both with and
+					// without the document's default credential and let
+					// the differential analyzer flag endpoints where
+					// that shouldn't have made a difference.
+					for _, c := range base {
+						unauthenticated := c
+						unauthenticated.bundle = recorder.BundleBaseline
+						authenticated := c
+						authenticated.bundle = recorder.BundleCandidate
+						authenticated.authProvider = authPlan.Probe
+						cases = append(cases, unauthenticated, authenticated)
+					}
+				default:
+					cases = append(cases, base...)
+				}
 			}
 		}
 	}
@@ -255,16 +230,13 @@ func main() {
 	// Create progress bar if not in verbose mode
 	var bar *progressbar.ProgressBar
 	if !*verbose {
-		bar = progressbar.Default(int64(totalRequests))
+		bar = progressbar.Default(int64(len(cases)))
 	}
 
+	rec := recorder.New()
+
 	// Create request channel and wait group
-	requestChan := make(chan struct {
-		method    string
-		url       string
-		body      []byte
-		headerMap map[string]string
-	})
+	requestChan := make(chan requestCase)
 	var wg sync.WaitGroup
 
 	// Start worker goroutines
@@ -285,15 +257,25 @@ func main() {
 				}
 
 				// Add default Content-Type header
-				request.Header.Set("Content-Type", "application/json")
+				request.Header.Set("Content-Type", req.contentType)
 
 				// Add custom headers
 				for key, value := range req.headerMap {
 					request.Header.Set(key, value)
 				}
+				if req.authProvider != nil {
+					req.authProvider.Apply(request)
+				}
 
 				// Send request
+				start := time.Now()
 				resp, err := client.Do(request)
+				latency := time.Since(start)
+				record := rec.Capture(req.bundle, req.method, req.url, req.contentType, req.body, resp, err, latency)
+				if resp != nil {
+					resp.Body.Close()
+				}
+
 				if err != nil {
 					if *verbose {
 						fmt.Printf("Error sending request: %v\n", err)
@@ -303,10 +285,9 @@ func main() {
 					}
 					continue
 				}
-				resp.Body.Close()
 
 				if *verbose {
-					fmt.Printf("%s %s -> %d\n", req.method, req.url, resp.StatusCode)
+					fmt.Printf("%s %s -> %d\n", req.method, req.url, record.Status)
 				} else {
 					bar.Add(1)
 				}
@@ -314,93 +295,203 @@ func main() {
 		}()
 	}
 
-	// Process each path
-	for path, pathItem := range api.Paths {
-		operations := map[string]*Operation{
-			"GET":    pathItem.Get,
-			"POST":   pathItem.Post,
-			"PUT":    pathItem.Put,
-			"DELETE": pathItem.Delete,
-			"PATCH":  pathItem.Patch,
+	for _, c := range cases {
+		requestChan <- c
+	}
+
+	// Close channel and wait for workers to finish
+	close(requestChan)
+	wg.Wait()
+
+	writeReportIfRequested(rec, *reportPath, *reportFormat)
+}
+
+// writeReportIfRequested writes rec's findings report to path in the given
+// format ("json" or "html"), or does nothing if path is empty.
+func writeReportIfRequested(rec *recorder.Recorder, path, format string) {
+	if path == "" {
+		return
+	}
+	writeReport(rec.Report(), path, format)
+}
+
+// writeReport writes report to path as JSON or HTML.
+func writeReport(report recorder.Report, path, format string) {
+	var err error
+	switch strings.ToLower(format) {
+	case "html":
+		err = report.WriteHTML(path)
+	default:
+		err = report.WriteJSON(path)
+	}
+	if err != nil {
+		fmt.Printf("Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseHeaderFlag parses a comma-separated "Key: value" header bundle, the
+// format -H-baseline/-H-candidate accept.
+func parseHeaderFlag(flagValue string) map[string]string {
+	headerMap := make(map[string]string)
+	if flagValue == "" {
+		return headerMap
+	}
+	for _, h := range strings.Split(flagValue, ",") {
+		parts := strings.SplitN(h, ":", 2)
+		if len(parts) == 2 {
+			headerMap[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
 		}
+	}
+	return headerMap
+}
 
-		for method, operation := range operations {
-			if operation == nil || !methodsMap[method] {
-				continue
-			}
+// parseHeaderList turns repeated -H "Key: value" occurrences into a header
+// map. Unlike parseHeaderFlag, a value is never comma-split, so a header
+// value that legitimately contains a comma (e.g. an Accept or Cookie
+// header) survives intact.
+func parseHeaderList(values []string) map[string]string {
+	headerMap := make(map[string]string, len(values))
+	for _, h := range values {
+		key, value, ok := strings.Cut(h, ":")
+		if ok {
+			headerMap[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return headerMap
+}
 
-			hasIntegers := hasIntegerParams(operation, pathItem.Parameters)
-			fuzzValues := []int{1000}
-			if *intFuzzing && hasIntegers {
-				fuzzValues = intFuzzValues
-			}
+// expandCases builds every request case for a single path x method x
+// strategy combination. Each declared parameter and the request body are
+// generated independently via the strategy; the widest resulting list
+// determines how many variants are produced, with narrower lists cycling
+// (the same broadcast behavior the original -int-fuzzing code had).
+func expandCases(normalizedHost, path, method string, pathItem openapi.PathItem, operation *openapi.Operation, strategy fuzz.Strategy, intFuzzing bool, overrides, headerMap map[string]string, authProvider auth.Provider) []requestCase {
+	allParams := mergeParameters(pathItem.Parameters, operation.Parameters)
 
-			for _, fuzzValue := range fuzzValues {
-				// Combine path and operation parameters
-				allParams := append(pathItem.Parameters, operation.Parameters...)
-
-				// Build request URL with path and query parameters
-				requestURL := normalizedHost + path
-				queryParams := url.Values{}
-				bodyParams := make(map[string]interface{})
-
-				for _, param := range allParams {
-					value := getDummyValue(param.Schema, param.Example, *intFuzzing, fuzzValue, param.Name, paramOverrideMap)
-
-					// For methods that typically have a body, put parameters in body instead of query
-					if (method == "POST" || method == "PUT" || method == "PATCH") && param.In == "query" {
-						bodyParams[param.Name] = value
-					} else {
-						switch param.In {
-						case "path":
-							requestURL = strings.Replace(requestURL, "{"+param.Name+"}", fmt.Sprint(value), -1)
-						case "query":
-							queryParams.Add(param.Name, fmt.Sprint(value))
-						}
-					}
-				}
+	paramVariants := make(map[*openapi.Parameter][]interface{}, len(allParams))
+	variantCount := 1
+	for _, param := range allParams {
+		ctx := &fuzz.Context{ParamName: param.Name, Overrides: overrides, IntFuzzing: intFuzzing}
+		values := strategy.Generate(param.Schema, ctx)
+		// An override (user -param-override or a -stateful capture) beats
+		// the spec's example, except under -int-fuzzing.
+		if _, overridden := overrides[param.Name]; param.Example != nil && (!overridden || intFuzzing) {
+			values = []interface{}{param.Example}
+		}
+		paramVariants[param] = values
+		if len(values) > variantCount {
+			variantCount = len(values)
+		}
+	}
 
-				if len(queryParams) > 0 {
-					requestURL += "?" + queryParams.Encode()
-				}
+	var bodySchema *openapi.Schema
+	bodyContentType := "application/json"
+	if operation.RequestBody != nil {
+		if mediaType, ok := operation.RequestBody.Content["application/json"]; ok {
+			bodySchema = mediaType.Schema
+		} else if mediaType, ok := operation.RequestBody.Content["application/x-www-form-urlencoded"]; ok {
+			bodySchema = mediaType.Schema
+			bodyContentType = "application/x-www-form-urlencoded"
+		}
+	}
+	var bodyVariants []interface{}
+	if bodySchema != nil {
+		ctx := &fuzz.Context{Overrides: overrides, IntFuzzing: intFuzzing}
+		bodyVariants = strategy.Generate(bodySchema, ctx)
+		if len(bodyVariants) > variantCount {
+			variantCount = len(bodyVariants)
+		}
+	}
 
-				// Create request body
-				var body []byte
-				if operation.RequestBody != nil {
-					content := operation.RequestBody["content"].(map[string]interface{})
-					if jsonContent, ok := content["application/json"]; ok {
-						schema := jsonContent.(map[string]interface{})["schema"].(map[string]interface{})
-						bodyData := getDummyValue(schema, nil, *intFuzzing, fuzzValue, "", paramOverrideMap)
-						body, _ = json.Marshal(bodyData)
-					}
-				} else if method == "POST" || method == "PUT" || method == "PATCH" {
-					// If no request body defined but method typically needs one, use collected body params or empty object
-					if len(bodyParams) > 0 {
-						body, _ = json.Marshal(bodyParams)
-					} else {
-						body = []byte("{}")
-					}
-				}
+	cases := make([]requestCase, 0, variantCount)
+	for i := 0; i < variantCount; i++ {
+		requestURL := normalizedHost + path
+		queryParams := url.Values{}
+		bodyParams := make(map[string]interface{})
 
-				// Send request to worker pool
-				requestChan <- struct {
-					method    string
-					url       string
-					body      []byte
-					headerMap map[string]string
-				}{
-					method:    method,
-					url:       requestURL,
-					body:      body,
-					headerMap: headerMap,
+		for _, param := range allParams {
+			values := paramVariants[param]
+			if len(values) == 0 {
+				continue
+			}
+			value := values[i%len(values)]
+
+			// For methods that typically have a body, put query-declared
+			// parameters in the body instead, matching the spec's intent
+			// when a write endpoint reuses query params as body fields.
+			if (method == "POST" || method == "PUT" || method == "PATCH") && param.In == "query" {
+				bodyParams[param.Name] = value
+			} else {
+				switch param.In {
+				case "path":
+					requestURL = strings.Replace(requestURL, "{"+param.Name+"}", fmt.Sprint(value), -1)
+				case "query":
+					queryParams.Add(param.Name, fmt.Sprint(value))
 				}
 			}
 		}
+
+		if len(queryParams) > 0 {
+			requestURL += "?" + queryParams.Encode()
+		}
+
+		var body []byte
+		switch {
+		case len(bodyVariants) > 0 && bodyContentType == "application/x-www-form-urlencoded":
+			body = []byte(encodeForm(bodyVariants[i%len(bodyVariants)]))
+		case len(bodyVariants) > 0:
+			body, _ = json.Marshal(bodyVariants[i%len(bodyVariants)])
+		case method == "POST" || method == "PUT" || method == "PATCH":
+			if len(bodyParams) > 0 {
+				body, _ = json.Marshal(bodyParams)
+			} else {
+				body = []byte("{}")
+			}
+		}
+
+		cases = append(cases, requestCase{
+			method:       method,
+			url:          requestURL,
+			body:         body,
+			contentType:  bodyContentType,
+			headerMap:    headerMap,
+			authProvider: authProvider,
+		})
 	}
+	return cases
+}
 
-	// Close channel and wait for workers to finish
-	close(requestChan)
-	wg.Wait()
+// encodeForm renders a strategy-generated object value as an
+// application/x-www-form-urlencoded body, for operations whose requestBody
+// came from a Swagger 2.0 formData conversion.
+func encodeForm(value interface{}) string {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	form := url.Values{}
+	for key, v := range fields {
+		form.Set(key, fmt.Sprint(v))
+	}
+	return form.Encode()
+}
+
+// mergeParameters applies path-item parameter inheritance: operation-level
+// parameters override a path-level parameter with the same name+location.
+func mergeParameters(pathParams, opParams []*openapi.Parameter) []*openapi.Parameter {
+	override := make(map[string]bool, len(opParams))
+	for _, p := range opParams {
+		override[p.In+"/"+p.Name] = true
+	}
+
+	merged := make([]*openapi.Parameter, 0, len(pathParams)+len(opParams))
+	for _, p := range pathParams {
+		if !override[p.In+"/"+p.Name] {
+			merged = append(merged, p)
+		}
+	}
+	return append(merged, opParams...)
 }
 
 // arrayFlags allows a flag to be specified multiple times
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/vrechson/SPR/internal/recorder"
+	"github.com/vrechson/SPR/internal/transport"
+)
+
+// runReplay implements -replay: load a prior -report JSON file and reissue
+// only the requests whose recorded status matches -replay-status, instead
+// of regenerating the whole fuzz matrix.
+func runReplay(client *http.Client, reportPath, statusFilter string, headerMap map[string]string, outPath, outFormat string) {
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		fmt.Printf("Error reading report to replay: %v\n", err)
+		os.Exit(1)
+	}
+
+	var report recorder.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		fmt.Printf("Error parsing report to replay: %v\n", err)
+		os.Exit(1)
+	}
+
+	filter, err := parseStatusFilter(statusFilter)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	records := transport.Replay(client, report, filter, headerMap)
+	for _, r := range records {
+		fmt.Printf("%s %s -> %d\n", r.Method, r.URL, r.Status)
+	}
+
+	if outPath != "" {
+		replayed := recorder.Report{Endpoints: []recorder.Endpoint{{Records: records}}}
+		writeReport(replayed, outPath, outFormat)
+	}
+}
+
+// parseStatusFilter turns a comma-separated status list (e.g. "500,502")
+// into a transport.StatusFilter; an empty spec matches everything.
+func parseStatusFilter(spec string) (transport.StatusFilter, error) {
+	if spec == "" {
+		return func(int) bool { return true }, nil
+	}
+	allowed := map[int]bool{}
+	for _, part := range strings.Split(spec, ",") {
+		status, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid -replay-status %q: %w", spec, err)
+		}
+		allowed[status] = true
+	}
+	return func(status int) bool { return allowed[status] }, nil
+}
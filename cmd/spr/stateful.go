@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vrechson/SPR/internal/auth"
+	"github.com/vrechson/SPR/internal/fuzz"
+	"github.com/vrechson/SPR/internal/openapi"
+	"github.com/vrechson/SPR/internal/recorder"
+	"github.com/vrechson/SPR/internal/stateful"
+)
+
+// runStateful drives a -stateful run: operations execute strictly in
+// dependency order (collection create, then item read/update, then
+// delete) instead of the concurrent worker pool, so that a POST's
+// generated id is available by the time the matching GET/DELETE fires.
+//
+// Unlike the concurrent path, this doesn't probe security:[] operations
+// both authenticated and unauthenticated - a chain depends on each step
+// actually succeeding, so every request uses its operation's resolved
+// auth (if any).
+func runStateful(client *http.Client, api *openapi.Document, authRegistry *auth.Registry, methodsMap map[string]bool, strategies []fuzz.Strategy, intFuzzing bool, paramOverrideMap, headerMap map[string]string, chains []stateful.ChainOverride, rec *recorder.Recorder, normalizedHost string, verbose bool) {
+	store := stateful.NewStore()
+	ops := stateful.Sequence(api.Paths, methodsMap)
+
+	for _, op := range ops {
+		// Merge stateful-inferred overrides on top of the user's own
+		// -param-override values, which always win on a name clash.
+		merged := make(map[string]string, len(paramOverrideMap))
+		for k, v := range stateful.Overrides(store, op, chains) {
+			merged[k] = v
+		}
+		for k, v := range paramOverrideMap {
+			merged[k] = v
+		}
+
+		authPlan := authRegistry.Resolve(op.Operation, api)
+		for _, strategy := range strategies {
+			cases := expandCases(normalizedHost, op.Path, op.Method, op.PathItem, op.Operation, strategy, intFuzzing, merged, headerMap, authPlan.Primary)
+			for _, c := range cases {
+				body := sendAndCapture(client, rec, c, verbose)
+				if body != nil {
+					captureSchema := responseSchema(op.Operation)
+					store.CaptureResponse(op.Resource, body, captureSchema)
+				}
+			}
+		}
+	}
+}
+
+// sendAndCapture issues a single request case, feeds it through the
+// recorder, and returns the raw response body so stateful capture can also
+// inspect it (the recorder itself only keeps a hash + length).
+func sendAndCapture(client *http.Client, rec *recorder.Recorder, c requestCase, verbose bool) []byte {
+	request, err := http.NewRequest(c.method, c.url, strings.NewReader(string(c.body)))
+	if err != nil {
+		if verbose {
+			fmt.Printf("Error creating request: %v\n", err)
+		}
+		return nil
+	}
+	request.Header.Set("Content-Type", c.contentType)
+	for key, value := range c.headerMap {
+		request.Header.Set(key, value)
+	}
+	if c.authProvider != nil {
+		c.authProvider.Apply(request)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(request)
+	latency := time.Since(start)
+	if err != nil {
+		rec.Capture(c.bundle, c.method, c.url, c.contentType, c.body, nil, err, latency)
+		if verbose {
+			fmt.Printf("Error sending request: %v\n", err)
+		}
+		return nil
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	replay := *resp
+	replay.Body = io.NopCloser(bytes.NewReader(body))
+	record := rec.Capture(c.bundle, c.method, c.url, c.contentType, c.body, &replay, nil, latency)
+
+	if verbose {
+		fmt.Printf("%s %s -> %d\n", c.method, c.url, record.Status)
+	}
+	if record.Status < 200 || record.Status >= 300 {
+		return nil
+	}
+	return body
+}
+
+// responseSchema returns the 2xx JSON response schema for an operation, if
+// any, so CaptureResponse can honor x-spr-capture hints.
+func responseSchema(op *openapi.Operation) *openapi.Schema {
+	for status, response := range op.Responses {
+		if len(status) == 0 || status[0] != '2' {
+			continue
+		}
+		if mediaType, ok := response.Content["application/json"]; ok {
+			return mediaType.Schema
+		}
+	}
+	return nil
+}
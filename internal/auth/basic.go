@@ -0,0 +1,14 @@
+package auth
+
+import "net/http"
+
+// BasicProvider implements `http` security schemes with `scheme: basic`,
+// e.g. -auth basic:user:pass.
+type BasicProvider struct {
+	Username string
+	Password string
+}
+
+func (p BasicProvider) Apply(req *http.Request) {
+	req.SetBasicAuth(p.Username, p.Password)
+}
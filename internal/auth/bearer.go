@@ -0,0 +1,13 @@
+package auth
+
+import "net/http"
+
+// BearerProvider implements `http` security schemes with `scheme: bearer`,
+// e.g. -auth bearer:$TOKEN.
+type BearerProvider struct {
+	Token string
+}
+
+func (p BearerProvider) Apply(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+p.Token)
+}
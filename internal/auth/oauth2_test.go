@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestOAuth2ProviderFetchesAndAppliesToken(t *testing.T) {
+	var gotContentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm: %v", err)
+		}
+		if r.PostForm.Get("grant_type") != "client_credentials" {
+			t.Errorf("grant_type = %q, want client_credentials", r.PostForm.Get("grant_type"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "tok-123", "expires_in": 3600}`))
+	}))
+	defer srv.Close()
+
+	provider := NewOAuth2Provider(OAuth2Config{TokenURL: srv.URL, ClientID: "id", ClientSecret: "secret"}, srv.Client())
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/pets", nil)
+	provider.Apply(req)
+
+	if got := req.Header.Get("Authorization"); got != "Bearer tok-123" {
+		t.Errorf("Authorization header = %q, want %q", got, "Bearer tok-123")
+	}
+	if gotContentType != "application/x-www-form-urlencoded" {
+		t.Errorf("token request Content-Type = %q, want application/x-www-form-urlencoded", gotContentType)
+	}
+}
+
+func TestOAuth2ProviderCachesTokenUntilExpiry(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"access_token": "tok-abc", "expires_in": 3600}`))
+	}))
+	defer srv.Close()
+
+	provider := NewOAuth2Provider(OAuth2Config{TokenURL: srv.URL}, srv.Client())
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/pets", nil)
+		provider.Apply(req)
+	}
+	if calls != 1 {
+		t.Errorf("token endpoint was called %d times, want 1 (cached token should be reused)", calls)
+	}
+}
+
+func TestOAuth2ProviderSwallowsFetchFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	provider := NewOAuth2Provider(OAuth2Config{TokenURL: srv.URL}, srv.Client())
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/pets", nil)
+	provider.Apply(req)
+
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization header = %q, want empty on fetch failure", got)
+	}
+}
+
+func TestOAuth2ProviderUsesConfiguredClient(t *testing.T) {
+	var sawRequest bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawRequest = true
+		w.Write([]byte(`{"access_token": "tok", "expires_in": 3600}`))
+	}))
+	defer srv.Close()
+
+	// A client whose Transport always errors proves Apply routes the token
+	// fetch through the injected client rather than http.DefaultClient.
+	brokenClient := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, &url.Error{Op: "post", URL: srv.URL, Err: http.ErrSchemeMismatch}
+	})}
+
+	provider := NewOAuth2Provider(OAuth2Config{TokenURL: srv.URL}, brokenClient)
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com/pets", nil)
+	provider.Apply(req)
+
+	if sawRequest {
+		t.Error("token request reached the real server; Apply should have used the injected (broken) client")
+	}
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization header = %q, want empty when the injected client's transport fails", got)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -0,0 +1,12 @@
+// Package auth wires the authentication schemes declared in an OpenAPI
+// document's components.securitySchemes into outgoing requests, based on
+// credentials supplied via repeatable -auth flags.
+package auth
+
+import "net/http"
+
+// Provider knows how to attach one kind of credential to an outgoing
+// request (a header, query parameter, or cookie).
+type Provider interface {
+	Apply(req *http.Request)
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/vrechson/SPR/internal/openapi"
+)
+
+// Registry holds one raw credential spec per auth kind (bearer, basic,
+// apikey, oauth2), as supplied via repeatable -auth flags, and builds the
+// concrete Provider required by whichever securityScheme an operation
+// actually declares.
+type Registry struct {
+	creds      map[string]string
+	httpClient *http.Client
+}
+
+// NewRegistry parses a list of "kind:credential" specs, e.g.
+// "bearer:$TOKEN" or "oauth2:token_url=...,client_id=...". httpClient is
+// used for any credential fetches the registry itself has to make (the
+// oauth2 client-credentials token request), so those go through the same
+// proxy/mTLS/TLS configuration as every other request SPR sends.
+func NewRegistry(specs []string, httpClient *http.Client) (*Registry, error) {
+	creds := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		kind, rest, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid -auth %q: expected kind:credential", spec)
+		}
+		creds[strings.ToLower(kind)] = rest
+	}
+	return &Registry{creds: creds, httpClient: httpClient}, nil
+}
+
+// Resolution is the auth plan for a single operation.
+type Resolution struct {
+	// Primary is what should normally be sent with the request (nil if the
+	// operation requires no auth, or SPR has no matching -auth credential).
+	Primary Provider
+	// Probe is an alternate credential worth trying alongside an
+	// unauthenticated request, for operations that explicitly opt out of
+	// auth (`security: []`) so the differential analyzer can flag missing
+	// enforcement. Nil unless the document has a default security scheme
+	// SPR can build a Provider for.
+	Probe Provider
+}
+
+// Resolve picks the auth plan for op, given the document it belongs to.
+// An operation that doesn't declare its own `security` inherits doc's
+// document-level default.
+func (r *Registry) Resolve(op *openapi.Operation, doc *openapi.Document) Resolution {
+	schemes := doc.Components.SecuritySchemes
+
+	if op.SecuritySet && len(op.Security) == 0 {
+		return Resolution{Probe: r.firstMatch(doc.Security, schemes)}
+	}
+
+	effective := op.Security
+	if !op.SecuritySet {
+		effective = doc.Security
+	}
+	return Resolution{Primary: r.firstMatch(effective, schemes)}
+}
+
+// firstMatch returns a Provider for the first security requirement SPR has
+// a matching -auth credential for, or nil if none apply.
+func (r *Registry) firstMatch(requirements []map[string][]string, schemes map[string]*openapi.SecurityScheme) Provider {
+	for _, requirement := range requirements {
+		for schemeName := range requirement {
+			scheme, ok := schemes[schemeName]
+			if !ok {
+				continue
+			}
+			if provider, ok := r.build(scheme); ok {
+				return provider
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Registry) build(scheme *openapi.SecurityScheme) (Provider, bool) {
+	switch {
+	case scheme.Type == "http" && scheme.Scheme == "bearer":
+		token, ok := r.creds["bearer"]
+		if !ok {
+			return nil, false
+		}
+		return BearerProvider{Token: token}, true
+
+	case scheme.Type == "http" && scheme.Scheme == "basic":
+		raw, ok := r.creds["basic"]
+		if !ok {
+			return nil, false
+		}
+		user, pass, _ := strings.Cut(raw, ":")
+		return BasicProvider{Username: user, Password: pass}, true
+
+	case scheme.Type == "apiKey":
+		value, ok := r.creds["apikey"]
+		if !ok {
+			return nil, false
+		}
+		return APIKeyProvider{In: scheme.In, Name: scheme.Name, Value: value}, true
+
+	case scheme.Type == "oauth2":
+		raw, ok := r.creds["oauth2"]
+		if !ok {
+			return nil, false
+		}
+		return NewOAuth2Provider(parseOAuth2Spec(raw, scheme), r.httpClient), true
+	}
+	return nil, false
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/vrechson/SPR/internal/openapi"
+)
+
+func TestResolveUsesBearerForMatchingScheme(t *testing.T) {
+	reg, err := NewRegistry([]string{"bearer:secret-token"}, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	doc := &openapi.Document{
+		Components: openapi.Components{
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+			},
+		},
+		Security: []map[string][]string{{"bearerAuth": {}}},
+	}
+	op := &openapi.Operation{}
+
+	res := reg.Resolve(op, doc)
+	if res.Primary == nil {
+		t.Fatal("Resolve returned no Primary provider for a matching bearer scheme")
+	}
+	if _, ok := res.Primary.(BearerProvider); !ok {
+		t.Errorf("Resolve returned %T, want BearerProvider", res.Primary)
+	}
+}
+
+func TestResolveExplicitEmptySecurityProbesDocumentDefault(t *testing.T) {
+	reg, err := NewRegistry([]string{"bearer:secret-token"}, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	doc := &openapi.Document{
+		Components: openapi.Components{
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+			},
+		},
+		Security: []map[string][]string{{"bearerAuth": {}}},
+	}
+	op := &openapi.Operation{SecuritySet: true, Security: []map[string][]string{}}
+
+	res := reg.Resolve(op, doc)
+	if res.Primary != nil {
+		t.Errorf("an explicitly public operation shouldn't get a Primary provider: %+v", res.Primary)
+	}
+	if res.Probe == nil {
+		t.Error("an explicitly public operation should still get a Probe from the document default")
+	}
+}
+
+func TestResolveInheritsDocumentDefaultWhenUnset(t *testing.T) {
+	reg, err := NewRegistry([]string{"apikey:my-key"}, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	doc := &openapi.Document{
+		Components: openapi.Components{
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				"apiKeyAuth": {Type: "apiKey", In: "header", Name: "X-API-Key"},
+			},
+		},
+		Security: []map[string][]string{{"apiKeyAuth": {}}},
+	}
+	op := &openapi.Operation{}
+
+	res := reg.Resolve(op, doc)
+	if res.Primary == nil {
+		t.Fatal("operation with no security set should inherit the document default")
+	}
+}
+
+func TestResolveNoMatchingCredentialReturnsNilProvider(t *testing.T) {
+	reg, err := NewRegistry(nil, http.DefaultClient)
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	doc := &openapi.Document{
+		Components: openapi.Components{
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+			},
+		},
+		Security: []map[string][]string{{"bearerAuth": {}}},
+	}
+	op := &openapi.Operation{}
+
+	res := reg.Resolve(op, doc)
+	if res.Primary != nil {
+		t.Errorf("Resolve should return a nil Primary when no -auth credential matches: %+v", res.Primary)
+	}
+}
+
+func TestNewRegistryRejectsInvalidSpec(t *testing.T) {
+	if _, err := NewRegistry([]string{"bearer-no-colon"}, http.DefaultClient); err == nil {
+		t.Error("NewRegistry should reject a spec without a kind:credential separator")
+	}
+}
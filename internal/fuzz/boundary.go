@@ -0,0 +1,48 @@
+package fuzz
+
+import (
+	"math"
+	"strings"
+
+	"github.com/vrechson/SPR/internal/openapi"
+)
+
+func init() {
+	register(boundaryStrategy{})
+}
+
+// boundaryStrategy probes the edges of whatever constraints a schema
+// declares: min/max +/- 1, zero-length and over-length strings, and the
+// signed 32/64-bit integer limits.
+type boundaryStrategy struct{}
+
+func (boundaryStrategy) Name() string { return "boundary" }
+
+func (boundaryStrategy) Generate(schema *openapi.Schema, ctx *Context) []interface{} {
+	return build(schema, nil, ctx, boundaryLeaf)
+}
+
+func boundaryLeaf(schema *openapi.Schema, _ *Context) []interface{} {
+	switch schema.Type {
+	case "integer", "number":
+		values := []interface{}{0, -1, math.MaxInt32, math.MaxInt64}
+		if schema.Minimum != nil {
+			values = append(values, int(*schema.Minimum), int(*schema.Minimum)-1)
+		}
+		if schema.Maximum != nil {
+			values = append(values, int(*schema.Maximum), int(*schema.Maximum)+1)
+		}
+		return values
+	case "string":
+		values := []interface{}{""}
+		if schema.MaxLength != nil {
+			values = append(values, strings.Repeat("a", *schema.MaxLength+1))
+		}
+		if schema.MinLength != nil && *schema.MinLength > 0 {
+			values = append(values, strings.Repeat("a", *schema.MinLength-1))
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+package fuzz
+
+import "github.com/vrechson/SPR/internal/openapi"
+
+func init() {
+	register(defaultStrategy{})
+}
+
+// defaultStrategy reproduces SPR's original behavior: a single dummy value
+// per field, except integer fields which expand to intFuzzValues when
+// -int-fuzzing is set.
+type defaultStrategy struct{}
+
+func (defaultStrategy) Name() string { return "default" }
+
+func (defaultStrategy) Generate(schema *openapi.Schema, ctx *Context) []interface{} {
+	return build(schema, nil, ctx, defaultLeaf)
+}
+
+func defaultLeaf(schema *openapi.Schema, ctx *Context) []interface{} {
+	if schema.Type != "integer" || !ctx.IntFuzzing {
+		return nil
+	}
+	if override, ok := ctx.Overrides[ctx.ParamName]; ok {
+		return []interface{}{override}
+	}
+	values := make([]interface{}, len(intFuzzValues))
+	for i, v := range intFuzzValues {
+		values[i] = v
+	}
+	return values
+}
+
+// intFuzzValues is the original hand-picked integer fuzz corpus.
+var intFuzzValues = []int{1, 10, 100, 1000, 10000, 100000, 1000000, 10000000,
+	2, 20, 200, 2000, 20000, 200000, 2000000, 20000000,
+	5, 50, 500, 5000, 50000, 500000, 5000000, 50000000,
+	9, 90, 900, 9000, 90000, 900000, 9000000, 90000000}
@@ -0,0 +1,25 @@
+package fuzz
+
+import "github.com/vrechson/SPR/internal/openapi"
+
+func init() {
+	register(enumStrategy{})
+}
+
+// enumStrategy tries every declared enum value plus one value guaranteed to
+// be outside the enum, to probe whether the server actually validates it.
+type enumStrategy struct{}
+
+func (enumStrategy) Name() string { return "enum" }
+
+func (enumStrategy) Generate(schema *openapi.Schema, ctx *Context) []interface{} {
+	return build(schema, nil, ctx, enumLeaf)
+}
+
+func enumLeaf(schema *openapi.Schema, _ *Context) []interface{} {
+	if len(schema.Enum) == 0 {
+		return nil
+	}
+	values := append([]interface{}{}, schema.Enum...)
+	return append(values, "__spr_not_in_enum__")
+}
@@ -0,0 +1,43 @@
+package fuzz
+
+import "github.com/vrechson/SPR/internal/openapi"
+
+func init() {
+	register(formatStrategy{})
+}
+
+// formatStrategy sends an RFC-correct sample value for each `format` keyword
+// SPR knows about, instead of the generic "test_string".
+type formatStrategy struct{}
+
+func (formatStrategy) Name() string { return "format" }
+
+func (formatStrategy) Generate(schema *openapi.Schema, ctx *Context) []interface{} {
+	return build(schema, nil, ctx, formatLeaf)
+}
+
+// formatSamples maps a JSON Schema / OpenAPI `format` value to a valid
+// example of it.
+var formatSamples = map[string]string{
+	"email":     "user@example.com",
+	"uri":       "https://example.com/resource",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"date":      "2024-01-01",
+	"date-time": "2024-01-01T00:00:00Z",
+	"uuid":      "11111111-1111-1111-1111-111111111111",
+	"hostname":  "example.com",
+	"byte":      "aGVsbG8=",
+	"binary":    "binary-data",
+}
+
+func formatLeaf(schema *openapi.Schema, _ *Context) []interface{} {
+	if schema.Type != "string" || schema.Format == "" {
+		return nil
+	}
+	sample, ok := formatSamples[schema.Format]
+	if !ok {
+		return nil
+	}
+	return []interface{}{sample}
+}
@@ -0,0 +1,52 @@
+package fuzz
+
+import "github.com/vrechson/SPR/internal/openapi"
+
+func init() {
+	register(injectionStrategy{})
+}
+
+// injectionStrategy sends common injection payloads into every string
+// field, regardless of format, so the report can flag endpoints that
+// reflect or choke on them.
+type injectionStrategy struct{}
+
+func (injectionStrategy) Name() string { return "injection" }
+
+func (injectionStrategy) Generate(schema *openapi.Schema, ctx *Context) []interface{} {
+	return build(schema, nil, ctx, injectionLeaf)
+}
+
+// injectionPayloads is deliberately small and well-known (no novel exploit
+// content) — it exists to surface obviously broken input handling, not to
+// be a serious exploitation toolkit.
+var injectionPayloads = []string{
+	// SQL injection
+	`' OR '1'='1`,
+	`'; DROP TABLE users; --`,
+	// XSS
+	`<script>alert(1)</script>`,
+	`"><img src=x onerror=alert(1)>`,
+	// SSRF
+	`http://169.254.169.254/latest/meta-data/`,
+	`http://localhost:22`,
+	// Path traversal
+	`../../../../etc/passwd`,
+	`..\..\..\..\windows\win.ini`,
+	// XXE
+	`<?xml version="1.0"?><!DOCTYPE a [<!ENTITY x SYSTEM "file:///etc/passwd">]><a>&x;</a>`,
+	// Template injection
+	`{{7*7}}`,
+	`${7*7}`,
+}
+
+func injectionLeaf(schema *openapi.Schema, _ *Context) []interface{} {
+	if schema.Type != "string" {
+		return nil
+	}
+	values := make([]interface{}, len(injectionPayloads))
+	for i, p := range injectionPayloads {
+		values[i] = p
+	}
+	return values
+}
@@ -0,0 +1,55 @@
+package fuzz
+
+import "github.com/vrechson/SPR/internal/openapi"
+
+func init() {
+	register(mutationStrategy{})
+}
+
+// mutationStrategy bit-flips and byte-swaps a seed string taken from the
+// schema's first enum value (or "test_string" when there is none), a
+// classic dumb-fuzzing pass that catches parsers choking on
+// malformed-but-plausible input.
+type mutationStrategy struct{}
+
+func (mutationStrategy) Name() string { return "mutation" }
+
+func (mutationStrategy) Generate(schema *openapi.Schema, ctx *Context) []interface{} {
+	return build(schema, nil, ctx, mutationLeaf)
+}
+
+func mutationLeaf(schema *openapi.Schema, _ *Context) []interface{} {
+	if schema.Type != "string" {
+		return nil
+	}
+	seed := "test_string"
+	if len(schema.Enum) > 0 {
+		if s, ok := schema.Enum[0].(string); ok {
+			seed = s
+		}
+	}
+
+	var values []interface{}
+	for i := 0; i < len(seed); i++ {
+		values = append(values, bitFlip(seed, i))
+	}
+	for i := 0; i+1 < len(seed); i++ {
+		values = append(values, byteSwap(seed, i, i+1))
+	}
+	if len(values) == 0 {
+		values = append(values, seed)
+	}
+	return values
+}
+
+func bitFlip(s string, pos int) string {
+	b := []byte(s)
+	b[pos] ^= 0x01
+	return string(b)
+}
+
+func byteSwap(s string, i, j int) string {
+	b := []byte(s)
+	b[i], b[j] = b[j], b[i]
+	return string(b)
+}
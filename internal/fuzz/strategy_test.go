@@ -0,0 +1,148 @@
+package fuzz
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vrechson/SPR/internal/openapi"
+)
+
+func floatPtr(f float64) *float64 { return &f }
+func intPtr(i int) *int           { return &i }
+
+func TestBoundaryStrategyIntegerLimits(t *testing.T) {
+	schema := &openapi.Schema{Type: "integer", Minimum: floatPtr(5), Maximum: floatPtr(10)}
+	values := boundaryLeaf(schema, &Context{})
+
+	want := map[interface{}]bool{0: false, -1: false, 4: false, 11: false, 5: false, 10: false}
+	for _, v := range values {
+		if _, ok := want[v]; ok {
+			want[v] = true
+		}
+	}
+	for v, seen := range want {
+		if !seen {
+			t.Errorf("boundaryLeaf missing expected value %v in %v", v, values)
+		}
+	}
+}
+
+func TestBoundaryStrategyStringLengths(t *testing.T) {
+	schema := &openapi.Schema{Type: "string", MinLength: intPtr(3), MaxLength: intPtr(5)}
+	values := boundaryLeaf(schema, &Context{})
+
+	var sawEmpty, sawTooLong, sawTooShort bool
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		switch {
+		case s == "":
+			sawEmpty = true
+		case len(s) == 6:
+			sawTooLong = true
+		case len(s) == 2:
+			sawTooShort = true
+		}
+	}
+	if !sawEmpty || !sawTooLong || !sawTooShort {
+		t.Errorf("boundaryLeaf didn't cover empty/over/under-length strings: %v", values)
+	}
+}
+
+func TestFormatStrategyKnownFormats(t *testing.T) {
+	cases := map[string]string{
+		"email":     "user@example.com",
+		"uuid":      "11111111-1111-1111-1111-111111111111",
+		"date-time": "2024-01-01T00:00:00Z",
+	}
+	for format, want := range cases {
+		schema := &openapi.Schema{Type: "string", Format: format}
+		values := formatLeaf(schema, &Context{})
+		if len(values) != 1 || values[0] != want {
+			t.Errorf("formatLeaf(%q) = %v, want [%q]", format, values, want)
+		}
+	}
+}
+
+func TestFormatStrategyUnknownFormatDefers(t *testing.T) {
+	schema := &openapi.Schema{Type: "string", Format: "not-a-real-format"}
+	if values := formatLeaf(schema, &Context{}); values != nil {
+		t.Errorf("formatLeaf on unknown format = %v, want nil (defer to default)", values)
+	}
+}
+
+func TestEnumStrategyAddsOutOfEnumValue(t *testing.T) {
+	schema := &openapi.Schema{Type: "string", Enum: []interface{}{"a", "b"}}
+	values := enumLeaf(schema, &Context{})
+	if len(values) != 3 {
+		t.Fatalf("enumLeaf returned %d values, want 3 (2 enum + 1 out-of-enum): %v", len(values), values)
+	}
+	last := values[len(values)-1]
+	for _, e := range schema.Enum {
+		if last == e {
+			t.Fatalf("enumLeaf's extra value %v is actually a declared enum member", last)
+		}
+	}
+}
+
+func TestMutationStrategyMutatesSeed(t *testing.T) {
+	schema := &openapi.Schema{Type: "string", Enum: []interface{}{"hello"}}
+	values := mutationLeaf(schema, &Context{})
+	if len(values) == 0 {
+		t.Fatal("mutationLeaf returned no variants for a non-empty seed")
+	}
+	sawMutation := false
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			t.Fatalf("mutationLeaf produced a non-string value: %v", v)
+		}
+		if len(s) != len("hello") {
+			t.Errorf("mutationLeaf changed the seed's length: %q", s)
+		}
+		if s != "hello" {
+			sawMutation = true
+		}
+	}
+	if !sawMutation {
+		t.Errorf("mutationLeaf never actually mutated the seed: %v", values)
+	}
+}
+
+func TestInjectionStrategyOnlyAppliesToStrings(t *testing.T) {
+	if values := injectionLeaf(&openapi.Schema{Type: "integer"}, &Context{}); values != nil {
+		t.Errorf("injectionLeaf on a non-string schema = %v, want nil", values)
+	}
+	values := injectionLeaf(&openapi.Schema{Type: "string"}, &Context{})
+	if len(values) != len(injectionPayloads) {
+		t.Fatalf("injectionLeaf returned %d values, want %d", len(values), len(injectionPayloads))
+	}
+	joined := strings.Join(injectionPayloads, "")
+	for _, v := range values {
+		if !strings.Contains(joined, v.(string)) {
+			t.Errorf("injectionLeaf produced a value not in injectionPayloads: %v", v)
+		}
+	}
+}
+
+func TestBuildBroadcastsVariantIndexAcrossFields(t *testing.T) {
+	schema := &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"a": {Type: "string", Enum: []interface{}{"x", "y"}},
+			"b": {Type: "string", Enum: []interface{}{"x", "y"}},
+		},
+	}
+	values := build(schema, nil, &Context{}, enumLeaf)
+	if len(values) != 3 {
+		t.Fatalf("build produced %d variants, want 3 (2 enum values + 1 out-of-enum)", len(values))
+	}
+	for i, v := range values {
+		obj := v.(map[string]interface{})
+		if obj["a"] != obj["b"] {
+			t.Errorf("variant %d: fields disagree (%v vs %v), want the same broadcast index for every leaf", i, obj["a"], obj["b"])
+		}
+	}
+}
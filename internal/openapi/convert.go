@@ -0,0 +1,219 @@
+package openapi
+
+import "fmt"
+
+// convertV2ToV3 rewrites a decoded Swagger 2.0 document into the OpenAPI 3.x
+// shape so the resolver and the rest of SPR only ever have to deal with one
+// schema version. It only handles the subset of v2 that affects fuzzing:
+// `host`/`basePath`/`schemes` -> `servers`, body/formData parameters ->
+// `requestBody`, `definitions` -> `components.schemas`,
+// `securityDefinitions` -> `components.securitySchemes`, and the top-level
+// `security` requirement, which is carried over unchanged.
+func convertV2ToV3(v2 map[string]interface{}) (map[string]interface{}, error) {
+	var copy map[string]interface{}
+	if err := marshalRoundTrip(v2, &copy); err != nil {
+		return nil, fmt.Errorf("copying v2 document: %w", err)
+	}
+
+	v3 := map[string]interface{}{
+		"openapi": "3.0.3",
+	}
+
+	v3["servers"] = convertServers(copy)
+
+	components := map[string]interface{}{}
+	if defs, ok := copy["definitions"].(map[string]interface{}); ok {
+		components["schemas"] = defs
+	}
+	if defs, ok := copy["securityDefinitions"].(map[string]interface{}); ok {
+		components["securitySchemes"] = convertSecuritySchemes(defs)
+	}
+	v3["components"] = components
+
+	if security, ok := copy["security"]; ok {
+		v3["security"] = security
+	}
+
+	paths, _ := copy["paths"].(map[string]interface{})
+	v3["paths"] = convertPaths(paths)
+
+	return v3, nil
+}
+
+// convertSecuritySchemes rewrites v2 securityDefinitions entries into v3
+// securitySchemes shape: "basic" becomes `http`+`scheme: basic`, and an
+// oauth2 "application" flow (the only flow SPR drives automatically)
+// becomes `flows.clientCredentials`. apiKey entries are already shaped the
+// same way in both versions.
+func convertSecuritySchemes(v2Schemes map[string]interface{}) map[string]interface{} {
+	v3Schemes := make(map[string]interface{}, len(v2Schemes))
+	for name, raw := range v2Schemes {
+		scheme, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch scheme["type"] {
+		case "basic":
+			v3Schemes[name] = map[string]interface{}{"type": "http", "scheme": "basic"}
+		case "oauth2":
+			if scheme["flow"] == "application" {
+				v3Schemes[name] = map[string]interface{}{
+					"type": "oauth2",
+					"flows": map[string]interface{}{
+						"clientCredentials": map[string]interface{}{
+							"tokenUrl": scheme["tokenUrl"],
+							"scopes":   scheme["scopes"],
+						},
+					},
+				}
+			} else {
+				v3Schemes[name] = scheme
+			}
+		default:
+			v3Schemes[name] = scheme
+		}
+	}
+	return v3Schemes
+}
+
+func convertServers(v2 map[string]interface{}) []interface{} {
+	host, _ := v2["host"].(string)
+	basePath, _ := v2["basePath"].(string)
+	if host == "" {
+		return []interface{}{}
+	}
+
+	scheme := "https"
+	if schemes, ok := v2["schemes"].([]interface{}); ok && len(schemes) > 0 {
+		if s, ok := schemes[0].(string); ok {
+			scheme = s
+		}
+	}
+	return []interface{}{
+		map[string]interface{}{"url": scheme + "://" + host + basePath},
+	}
+}
+
+func convertPaths(v2Paths map[string]interface{}) map[string]interface{} {
+	v3Paths := make(map[string]interface{}, len(v2Paths))
+	for path, rawItem := range v2Paths {
+		item, ok := rawItem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		v3Item := make(map[string]interface{})
+		for key, val := range item {
+			op, ok := val.(map[string]interface{})
+			if !ok || !isHTTPMethod(key) {
+				v3Item[key] = val
+				continue
+			}
+			v3Item[key] = convertOperation(op)
+		}
+		v3Paths[path] = v3Item
+	}
+	return v3Paths
+}
+
+func isHTTPMethod(key string) bool {
+	switch key {
+	case "get", "put", "post", "delete", "options", "head", "patch":
+		return true
+	}
+	return false
+}
+
+// schemaKeys are the v2 parameter fields that sit directly on a non-body
+// parameter object but live under v3 Parameter.Schema instead.
+var schemaKeys = []string{"type", "format", "items", "enum", "minimum", "maximum", "minLength", "maxLength"}
+
+// wrapParameterSchema nests a v2 query/path/header parameter's leftover
+// type/format/items/enum/min/max fields under a v3-shaped `schema` object,
+// since openapi.Parameter (types.go) only reads that information out of
+// Schema, never off the parameter itself.
+func wrapParameterSchema(param map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{}
+	out := make(map[string]interface{}, len(param))
+	for key, val := range param {
+		if contains(schemaKeys, key) {
+			schema[key] = val
+			continue
+		}
+		out[key] = val
+	}
+	if len(schema) > 0 {
+		out["schema"] = schema
+	}
+	return out
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// convertOperation pulls v2 `body`/`formData` parameters out of the
+// `parameters` array and turns them into a v3 `requestBody`.
+func convertOperation(op map[string]interface{}) map[string]interface{} {
+	rawParams, _ := op["parameters"].([]interface{})
+
+	var remaining []interface{}
+	formProps := map[string]interface{}{}
+	var bodySchema interface{}
+
+	for _, rp := range rawParams {
+		param, ok := rp.(map[string]interface{})
+		if !ok {
+			remaining = append(remaining, rp)
+			continue
+		}
+		switch param["in"] {
+		case "body":
+			bodySchema = param["schema"]
+		case "formData":
+			name, _ := param["name"].(string)
+			schema := map[string]interface{}{"type": param["type"]}
+			if fmtv, ok := param["format"]; ok {
+				schema["format"] = fmtv
+			}
+			formProps[name] = schema
+		default:
+			remaining = append(remaining, wrapParameterSchema(param))
+		}
+	}
+
+	out := make(map[string]interface{}, len(op))
+	for key, val := range op {
+		if key == "parameters" {
+			continue
+		}
+		out[key] = val
+	}
+	out["parameters"] = remaining
+
+	switch {
+	case bodySchema != nil:
+		out["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": bodySchema},
+			},
+		}
+	case len(formProps) > 0:
+		out["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"application/x-www-form-urlencoded": map[string]interface{}{
+					"schema": map[string]interface{}{
+						"type":       "object",
+						"properties": formProps,
+					},
+				},
+			},
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,142 @@
+package openapi
+
+import "testing"
+
+func TestConvertV2ToV3FormDataBecomesRequestBody(t *testing.T) {
+	v2 := map[string]interface{}{
+		"swagger": "2.0",
+		"host":    "api.example.com",
+		"paths": map[string]interface{}{
+			"/upload": map[string]interface{}{
+				"post": map[string]interface{}{
+					"parameters": []interface{}{
+						map[string]interface{}{"name": "file", "in": "formData", "type": "string"},
+					},
+				},
+			},
+		},
+	}
+
+	v3, err := convertV2ToV3(v2)
+	if err != nil {
+		t.Fatalf("convertV2ToV3: %v", err)
+	}
+
+	op := v3["paths"].(map[string]interface{})["/upload"].(map[string]interface{})["post"].(map[string]interface{})
+	reqBody, ok := op["requestBody"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("formData parameter didn't produce a requestBody: %+v", op)
+	}
+	content := reqBody["content"].(map[string]interface{})
+	if _, ok := content["application/x-www-form-urlencoded"]; !ok {
+		t.Fatalf("requestBody.content missing application/x-www-form-urlencoded: %+v", content)
+	}
+}
+
+func TestConvertV2ToV3WrapsQueryParameterIntoSchema(t *testing.T) {
+	v2 := map[string]interface{}{
+		"swagger": "2.0",
+		"host":    "api.example.com",
+		"paths": map[string]interface{}{
+			"/pets": map[string]interface{}{
+				"get": map[string]interface{}{
+					"parameters": []interface{}{
+						map[string]interface{}{
+							"name": "limit", "in": "query", "required": false,
+							"type": "integer", "minimum": 1, "maximum": 100,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	v3, err := convertV2ToV3(v2)
+	if err != nil {
+		t.Fatalf("convertV2ToV3: %v", err)
+	}
+
+	op := v3["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["get"].(map[string]interface{})
+	params := op["parameters"].([]interface{})
+	if len(params) != 1 {
+		t.Fatalf("got %d parameters, want 1: %+v", len(params), params)
+	}
+	param := params[0].(map[string]interface{})
+	if param["name"] != "limit" || param["in"] != "query" {
+		t.Fatalf("parameter name/in weren't preserved: %+v", param)
+	}
+	schema, ok := param["schema"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("converted parameter has no nested schema: %+v", param)
+	}
+	if schema["type"] != "integer" || schema["minimum"] != float64(1) || schema["maximum"] != float64(100) {
+		t.Errorf("schema didn't pick up type/minimum/maximum: %+v", schema)
+	}
+	if _, leaked := param["type"]; leaked {
+		t.Errorf("type should have been moved into schema, not left on the parameter: %+v", param)
+	}
+}
+
+func TestConvertSecuritySchemesBasicAndOAuth2(t *testing.T) {
+	v2Schemes := map[string]interface{}{
+		"basicAuth": map[string]interface{}{"type": "basic"},
+		"oauth2App": map[string]interface{}{
+			"type":     "oauth2",
+			"flow":     "application",
+			"tokenUrl": "https://auth.example.com/token",
+			"scopes":   map[string]interface{}{"read": "read access"},
+		},
+		"apiKeyAuth": map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+	}
+
+	v3Schemes := convertSecuritySchemes(v2Schemes)
+
+	basic := v3Schemes["basicAuth"].(map[string]interface{})
+	if basic["type"] != "http" || basic["scheme"] != "basic" {
+		t.Errorf("basicAuth didn't convert to http+basic: %+v", basic)
+	}
+
+	oauth2 := v3Schemes["oauth2App"].(map[string]interface{})
+	if oauth2["type"] != "oauth2" {
+		t.Fatalf("oauth2App.type = %v, want oauth2", oauth2["type"])
+	}
+	flows, ok := oauth2["flows"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("oauth2App missing flows: %+v", oauth2)
+	}
+	cc, ok := flows["clientCredentials"].(map[string]interface{})
+	if !ok || cc["tokenUrl"] != "https://auth.example.com/token" {
+		t.Errorf("application flow didn't convert to clientCredentials with the right tokenUrl: %+v", flows)
+	}
+
+	apiKey := v3Schemes["apiKeyAuth"].(map[string]interface{})
+	if apiKey["type"] != "apiKey" || apiKey["in"] != "header" || apiKey["name"] != "X-API-Key" {
+		t.Errorf("apiKeyAuth should pass through unchanged: %+v", apiKey)
+	}
+}
+
+func TestConvertV2ToV3CarriesSecurityRequirement(t *testing.T) {
+	v2 := map[string]interface{}{
+		"swagger": "2.0",
+		"host":    "api.example.com",
+		"security": []interface{}{
+			map[string]interface{}{"basicAuth": []interface{}{}},
+		},
+		"securityDefinitions": map[string]interface{}{
+			"basicAuth": map[string]interface{}{"type": "basic"},
+		},
+		"paths": map[string]interface{}{},
+	}
+
+	v3, err := convertV2ToV3(v2)
+	if err != nil {
+		t.Fatalf("convertV2ToV3: %v", err)
+	}
+	if _, ok := v3["security"]; !ok {
+		t.Errorf("top-level `security` requirement was dropped during v2->v3 conversion: %+v", v3)
+	}
+	components := v3["components"].(map[string]interface{})
+	if _, ok := components["securitySchemes"]; !ok {
+		t.Errorf("securityDefinitions wasn't converted into components.securitySchemes: %+v", components)
+	}
+}
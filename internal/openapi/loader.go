@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads the spec at path, auto-detecting JSON vs YAML and Swagger 2.0
+// vs OpenAPI 3.x, and returns a fully $ref-resolved Document.
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading spec: %w", err)
+	}
+
+	raw, err := decode(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding spec: %w", err)
+	}
+
+	if version, _ := raw["swagger"].(string); version == "2.0" {
+		raw, err = convertV2ToV3(raw)
+		if err != nil {
+			return nil, fmt.Errorf("converting swagger 2.0 spec: %w", err)
+		}
+	}
+
+	resolved, err := newResolver(filepath.Dir(path)).resolve(raw)
+	if err != nil {
+		return nil, fmt.Errorf("resolving $ref: %w", err)
+	}
+
+	reencoded, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc Document
+	if err := json.Unmarshal(reencoded, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling resolved spec: %w", err)
+	}
+	markExplicitSecurity(resolved, &doc)
+	return &doc, nil
+}
+
+// decode sniffs the content type by file extension first, falling back to
+// looking for a leading '{' which unambiguously means JSON.
+func decode(path string, data []byte) (map[string]interface{}, error) {
+	isJSON := strings.EqualFold(filepath.Ext(path), ".json")
+	if !isJSON {
+		trimmed := bytes.TrimSpace(data)
+		isJSON = len(trimmed) > 0 && trimmed[0] == '{'
+	}
+
+	out := make(map[string]interface{})
+	if isJSON {
+		if err := json.Unmarshal(data, &out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// markExplicitSecurity records, per operation, whether `security` was
+// present in the raw document at all. json.Unmarshal can't distinguish a
+// missing field from an empty slice, but the auth subsystem needs that
+// distinction: an explicit `security: []` means "unauthenticated", while a
+// missing key means "inherit the document-level default". raw must be the
+// $ref-resolved tree, not the pre-resolution document: a path item reached
+// only via `$ref` has no `get`/`post` keys of its own before resolution, so
+// looking it up in the raw document would always miss and silently treat
+// every such operation as "inherit the default".
+func markExplicitSecurity(raw map[string]interface{}, doc *Document) {
+	paths, _ := raw["paths"].(map[string]interface{})
+	for path, pathItem := range doc.Paths {
+		rawPathItem, _ := paths[path].(map[string]interface{})
+		for method, op := range pathItem.Operations() {
+			rawOp, _ := rawPathItem[strings.ToLower(method)].(map[string]interface{})
+			if rawOp == nil {
+				continue
+			}
+			_, op.SecuritySet = rawOp["security"]
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+	return path
+}
+
+func TestLoadResolvesLocalRef(t *testing.T) {
+	dir := t.TempDir()
+	spec := writeFile(t, dir, "spec.json", `{
+		"openapi": "3.0.0",
+		"servers": [{"url": "https://api.example.com"}],
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [{"$ref": "#/components/parameters/Limit"}],
+					"responses": {}
+				}
+			}
+		},
+		"components": {
+			"parameters": {
+				"Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}
+			}
+		}
+	}`)
+
+	doc, err := Load(spec)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	params := doc.Paths["/pets"].Get.Parameters
+	if len(params) != 1 || params[0].Name != "limit" || params[0].In != "query" {
+		t.Fatalf("local $ref wasn't resolved into the parameter, got %+v", params)
+	}
+}
+
+func TestLoadResolvesExternalFileRef(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "common.json", `{
+		"Pet": {"type": "object", "properties": {"id": {"type": "string"}}}
+	}`)
+	spec := writeFile(t, dir, "spec.json", `{
+		"openapi": "3.0.0",
+		"servers": [{"url": "https://api.example.com"}],
+		"paths": {
+			"/pets": {
+				"post": {
+					"requestBody": {
+						"content": {"application/json": {"schema": {"$ref": "common.json#/Pet"}}}
+					},
+					"responses": {}
+				}
+			}
+		},
+		"components": {}
+	}`)
+
+	doc, err := Load(spec)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	schema := doc.Paths["/pets"].Post.RequestBody.Content["application/json"].Schema
+	if schema == nil || schema.Type != "object" {
+		t.Fatalf("external $ref wasn't resolved into the request body schema, got %+v", schema)
+	}
+	if _, ok := schema.Properties["id"]; !ok {
+		t.Fatalf("external ref's Pet.properties.id is missing: %+v", schema.Properties)
+	}
+}
+
+func TestLoadMarksExplicitSecurityThroughPathItemRef(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pets.json", `{
+		"get": {"security": [], "responses": {}}
+	}`)
+	spec := writeFile(t, dir, "spec.json", `{
+		"openapi": "3.0.0",
+		"servers": [{"url": "https://api.example.com"}],
+		"paths": {
+			"/pets": {"$ref": "pets.json"}
+		},
+		"components": {}
+	}`)
+
+	doc, err := Load(spec)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	get := doc.Paths["/pets"].Get
+	if !get.SecuritySet || len(get.Security) != 0 {
+		t.Errorf("/pets (via $ref): SecuritySet=%v Security=%v, want SecuritySet=true, Security=[]", get.SecuritySet, get.Security)
+	}
+}
+
+func TestLoadMarksExplicitEmptySecurity(t *testing.T) {
+	dir := t.TempDir()
+	spec := writeFile(t, dir, "spec.json", `{
+		"openapi": "3.0.0",
+		"servers": [{"url": "https://api.example.com"}],
+		"paths": {
+			"/public": {"get": {"security": [], "responses": {}}},
+			"/default": {"get": {"responses": {}}}
+		},
+		"components": {}
+	}`)
+
+	doc, err := Load(spec)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	public := doc.Paths["/public"].Get
+	if !public.SecuritySet || len(public.Security) != 0 {
+		t.Errorf("/public: SecuritySet=%v Security=%v, want SecuritySet=true, Security=[]", public.SecuritySet, public.Security)
+	}
+	def := doc.Paths["/default"].Get
+	if def.SecuritySet {
+		t.Errorf("/default: SecuritySet=true, want false (no `security` key present -> inherit document default)")
+	}
+}
@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// resolver walks a decoded spec (as generic map[string]interface{} /
+// []interface{} trees) and replaces every `$ref` node with the object it
+// points at, inlining external files relative to baseDir along the way.
+//
+// Local refs are resolved against the *root* document being walked, which
+// means a $ref found inside a file pulled in by another $ref still resolves
+// against that file's own root, matching the JSON Reference spec.
+type resolver struct {
+	baseDir  string
+	external map[string]map[string]interface{}
+	seen     map[string]bool
+}
+
+func newResolver(baseDir string) *resolver {
+	return &resolver{
+		baseDir:  baseDir,
+		external: map[string]map[string]interface{}{},
+		seen:     map[string]bool{},
+	}
+}
+
+func (r *resolver) resolve(root map[string]interface{}) (map[string]interface{}, error) {
+	resolved, err := r.walk(root, root, r.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	out, ok := resolved.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("resolved document root is not an object")
+	}
+	return out, nil
+}
+
+func (r *resolver) walk(node interface{}, root map[string]interface{}, dir string) (interface{}, error) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			return r.resolveRef(ref, root, dir)
+		}
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			resolvedVal, err := r.walk(val, root, dir)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = resolvedVal
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			resolvedVal, err := r.walk(val, root, dir)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolvedVal
+		}
+		return out, nil
+	default:
+		return node, nil
+	}
+}
+
+// resolveRef dereferences a single $ref string, which is either
+// "#/components/schemas/Foo" (local), "other.yaml#/..." (file-relative) or
+// a bare "other.yaml" file reference.
+func (r *resolver) resolveRef(ref string, root map[string]interface{}, dir string) (interface{}, error) {
+	if r.seen[ref] {
+		return nil, fmt.Errorf("circular $ref detected: %s", ref)
+	}
+	r.seen[ref] = true
+	defer delete(r.seen, ref)
+
+	filePart, pointer, _ := strings.Cut(ref, "#")
+
+	target := root
+	targetDir := dir
+	if filePart != "" {
+		resolvedPath := filepath.Join(dir, filePart)
+		doc, err := r.loadExternal(resolvedPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading ref target %q: %w", ref, err)
+		}
+		target = doc
+		targetDir = filepath.Dir(resolvedPath)
+	}
+
+	node, err := lookupPointer(target, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %q: %w", ref, err)
+	}
+	return r.walk(node, target, targetDir)
+}
+
+func (r *resolver) loadExternal(path string) (map[string]interface{}, error) {
+	if doc, ok := r.external[path]; ok {
+		return doc, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decode(path, data)
+	if err != nil {
+		return nil, err
+	}
+	r.external[path] = doc
+	return doc, nil
+}
+
+// lookupPointer resolves a JSON Pointer such as "/components/schemas/Foo"
+// against root. An empty pointer (or "/") returns root itself.
+func lookupPointer(root map[string]interface{}, pointer string) (interface{}, error) {
+	pointer = strings.TrimPrefix(pointer, "#")
+	if pointer == "" || pointer == "/" {
+		return root, nil
+	}
+
+	var current interface{} = root
+	for _, rawSegment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		segment := strings.ReplaceAll(strings.ReplaceAll(rawSegment, "~1", "/"), "~0", "~")
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[segment]
+			if !ok {
+				return nil, fmt.Errorf("no such key %q in pointer path", segment)
+			}
+			current = next
+		case []interface{}:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, fmt.Errorf("invalid array index %q in pointer path", segment)
+			}
+			current = node[idx]
+		default:
+			return nil, fmt.Errorf("cannot descend into %q: not an object or array", segment)
+		}
+	}
+	return current, nil
+}
+
+// marshalRoundTrip is a small helper used by callers that need a deep copy
+// of a decoded tree (e.g. before mutating it in convertV2ToV3).
+func marshalRoundTrip(in interface{}, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,223 @@
+// Package openapi loads OpenAPI 3.x and Swagger 2.0 documents into a single
+// normalized in-memory model that the rest of SPR fuzzes against.
+package openapi
+
+// Document is the normalized OpenAPI 3.x model. Swagger 2.0 input is
+// converted into this shape during Load so downstream code never has to
+// special-case the source version.
+type Document struct {
+	OpenAPI    string              `json:"openapi" yaml:"openapi"`
+	Servers    []Server            `json:"servers" yaml:"servers"`
+	Paths      map[string]PathItem `json:"paths" yaml:"paths"`
+	Components Components          `json:"components" yaml:"components"`
+	// Security is the document-level default security requirement,
+	// inherited by any operation that doesn't declare its own `security`.
+	Security []map[string][]string `json:"security" yaml:"security"`
+}
+
+// Server is a single entry of the top-level (or path-level) `servers` array.
+type Server struct {
+	URL string `json:"url" yaml:"url"`
+}
+
+// Components holds the reusable objects referenced via $ref.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas" yaml:"schemas"`
+	Parameters      map[string]*Parameter      `json:"parameters" yaml:"parameters"`
+	RequestBodies   map[string]*RequestBody    `json:"requestBodies" yaml:"requestBodies"`
+	Responses       map[string]*Response       `json:"responses" yaml:"responses"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes" yaml:"securitySchemes"`
+}
+
+// PathItem is a single entry of the `paths` map. Parameters declared here
+// apply to every operation below unless the operation redeclares the same
+// name+location.
+type PathItem struct {
+	Ref        string       `json:"$ref" yaml:"$ref"`
+	Parameters []*Parameter `json:"parameters" yaml:"parameters"`
+	Get        *Operation   `json:"get" yaml:"get"`
+	Put        *Operation   `json:"put" yaml:"put"`
+	Post       *Operation   `json:"post" yaml:"post"`
+	Delete     *Operation   `json:"delete" yaml:"delete"`
+	Options    *Operation   `json:"options" yaml:"options"`
+	Head       *Operation   `json:"head" yaml:"head"`
+	Patch      *Operation   `json:"patch" yaml:"patch"`
+}
+
+// Operations returns the non-nil HTTP method operations keyed by their
+// upper-case method name. The result is a map, so ranging over it visits
+// the methods in no fixed order.
+func (p PathItem) Operations() map[string]*Operation {
+	ops := make(map[string]*Operation)
+	add := func(method string, op *Operation) {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+	add("GET", p.Get)
+	add("PUT", p.Put)
+	add("POST", p.Post)
+	add("DELETE", p.Delete)
+	add("OPTIONS", p.Options)
+	add("HEAD", p.Head)
+	add("PATCH", p.Patch)
+	return ops
+}
+
+// Operation is a single method on a path.
+type Operation struct {
+	OperationID string                `json:"operationId" yaml:"operationId"`
+	Parameters  []*Parameter          `json:"parameters" yaml:"parameters"`
+	RequestBody *RequestBody          `json:"requestBody" yaml:"requestBody"`
+	Responses   map[string]*Response  `json:"responses" yaml:"responses"`
+	Security    []map[string][]string `json:"security" yaml:"security"`
+	// SecuritySet reports whether `security` was present on the operation at
+	// all, as opposed to an explicit empty `[]` (public endpoint). A nil
+	// Security with SecuritySet==false means "inherit the document default".
+	SecuritySet bool `json:"-" yaml:"-"`
+}
+
+// Parameter is a path, query, header or cookie parameter.
+type Parameter struct {
+	Ref       string      `json:"$ref" yaml:"$ref"`
+	Name      string      `json:"name" yaml:"name"`
+	In        string      `json:"in" yaml:"in"`
+	Required  bool        `json:"required" yaml:"required"`
+	Schema    *Schema     `json:"schema" yaml:"schema"`
+	Example   interface{} `json:"example" yaml:"example"`
+	// XSPRInject names a stateful.Store key whose captured value is
+	// substituted for this parameter in -stateful mode. It is tried before
+	// the id name heuristics; only an explicit -chain takes precedence.
+	XSPRInject string `json:"x-spr-inject,omitempty" yaml:"x-spr-inject,omitempty"`
+}
+
+// RequestBody is a `requestBody` object keyed by media type.
+type RequestBody struct {
+	Ref      string               `json:"$ref" yaml:"$ref"`
+	Required bool                 `json:"required" yaml:"required"`
+	Content  map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// Response is a single entry of an operation's `responses` map.
+type Response struct {
+	Ref     string               `json:"$ref" yaml:"$ref"`
+	Content map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// MediaType is one entry of a `content` map, e.g. "application/json".
+type MediaType struct {
+	Schema *Schema `json:"schema" yaml:"schema"`
+}
+
+// Schema is a (partial) JSON Schema as used by OpenAPI: it additionally
+// understands `nullable` and the `allOf`/`oneOf`/`anyOf` composition
+// keywords, which are resolved by Flatten before being handed to the fuzz
+// package.
+type Schema struct {
+	Ref        string             `json:"$ref" yaml:"$ref"`
+	Type       string             `json:"type" yaml:"type"`
+	Format     string             `json:"format" yaml:"format"`
+	Nullable   bool               `json:"nullable" yaml:"nullable"`
+	Properties map[string]*Schema `json:"properties" yaml:"properties"`
+	Items      *Schema            `json:"items" yaml:"items"`
+	Enum       []interface{}      `json:"enum" yaml:"enum"`
+	Minimum    *float64           `json:"minimum" yaml:"minimum"`
+	Maximum    *float64           `json:"maximum" yaml:"maximum"`
+	MinLength  *int               `json:"minLength" yaml:"minLength"`
+	MaxLength  *int               `json:"maxLength" yaml:"maxLength"`
+	Required   []string           `json:"required" yaml:"required"`
+	AllOf      []*Schema          `json:"allOf" yaml:"allOf"`
+	OneOf      []*Schema          `json:"oneOf" yaml:"oneOf"`
+	AnyOf      []*Schema          `json:"anyOf" yaml:"anyOf"`
+	// XSPRCapture names the stateful.Store key a successful response's
+	// value at this schema node should be saved under.
+	XSPRCapture string `json:"x-spr-capture,omitempty" yaml:"x-spr-capture,omitempty"`
+}
+
+// Flatten merges allOf members into a single schema and picks the first
+// branch of oneOf/anyOf, so that callers only ever need to deal with plain
+// type/properties/format fields. From each member only type and format
+// (when s has none), properties and, for allOf, required are taken; items,
+// enum and the numeric/length bounds come from s alone. The original
+// schema is left untouched.
+func (s *Schema) Flatten() *Schema {
+	if s == nil {
+		return nil
+	}
+	merged := &Schema{
+		Type:        s.Type,
+		Format:      s.Format,
+		Nullable:    s.Nullable,
+		Properties:  map[string]*Schema{},
+		Items:       s.Items,
+		Enum:        s.Enum,
+		Minimum:     s.Minimum,
+		Maximum:     s.Maximum,
+		MinLength:   s.MinLength,
+		MaxLength:   s.MaxLength,
+		Required:    s.Required,
+		XSPRCapture: s.XSPRCapture,
+	}
+	for k, v := range s.Properties {
+		merged.Properties[k] = v
+	}
+
+	branch := s.OneOf
+	if len(branch) == 0 {
+		branch = s.AnyOf
+	}
+	if len(branch) > 0 && branch[0] != nil {
+		flat := branch[0].Flatten()
+		if merged.Type == "" {
+			merged.Type = flat.Type
+		}
+		if merged.Format == "" {
+			merged.Format = flat.Format
+		}
+		for k, v := range flat.Properties {
+			merged.Properties[k] = v
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		if sub == nil {
+			continue
+		}
+		flat := sub.Flatten()
+		if merged.Type == "" {
+			merged.Type = flat.Type
+		}
+		if merged.Format == "" {
+			merged.Format = flat.Format
+		}
+		for k, v := range flat.Properties {
+			merged.Properties[k] = v
+		}
+		merged.Required = append(merged.Required, flat.Required...)
+	}
+
+	if merged.Type == "" && len(merged.Properties) > 0 {
+		merged.Type = "object"
+	}
+	return merged
+}
+
+// SecurityScheme is an entry of `components.securitySchemes`.
+type SecurityScheme struct {
+	Type   string     `json:"type" yaml:"type"`
+	Scheme string     `json:"scheme" yaml:"scheme"`
+	In     string     `json:"in" yaml:"in"`
+	Name   string     `json:"name" yaml:"name"`
+	Flows  OAuthFlows `json:"flows" yaml:"flows"`
+}
+
+// OAuthFlows holds the flow objects SPR knows how to drive automatically.
+type OAuthFlows struct {
+	ClientCredentials *OAuthFlow `json:"clientCredentials" yaml:"clientCredentials"`
+}
+
+// OAuthFlow describes a single OAuth2 flow's endpoints and scopes.
+type OAuthFlow struct {
+	TokenURL string            `json:"tokenUrl" yaml:"tokenUrl"`
+	Scopes   map[string]string `json:"scopes" yaml:"scopes"`
+}
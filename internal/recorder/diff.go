@@ -0,0 +1,54 @@
+package recorder
+
+// DiffFinding flags a request that got an equivalent response whether or
+// not it carried the candidate auth headers — a classic broken-
+// authorization / BOLA signal.
+type DiffFinding struct {
+	Endpoint        string `json:"endpoint"`
+	BaselineStatus  int    `json:"baseline_status"`
+	CandidateStatus int    `json:"candidate_status"`
+	Note            string `json:"note"`
+}
+
+// Diff pairs up every BundleBaseline record with the BundleCandidate record
+// for the same method+URL+request body and flags pairs whose responses are
+// byte-identical or status-equivalent.
+func Diff(records []Record) []DiffFinding {
+	baseline := map[string]Record{}
+	candidate := map[string]Record{}
+	for _, rec := range records {
+		key := rec.Method + " " + rec.URL + " " + rec.RequestBody
+		switch rec.Bundle {
+		case BundleBaseline:
+			baseline[key] = rec
+		case BundleCandidate:
+			candidate[key] = rec
+		}
+	}
+
+	var findings []DiffFinding
+	for key, b := range baseline {
+		c, ok := candidate[key]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case b.ResponseHash != "" && b.ResponseHash == c.ResponseHash:
+			findings = append(findings, DiffFinding{
+				Endpoint:        b.Method + " " + b.URL,
+				BaselineStatus:  b.Status,
+				CandidateStatus: c.Status,
+				Note:            "responses are byte-identical across baseline and candidate headers",
+			})
+		case b.Status == c.Status:
+			findings = append(findings, DiffFinding{
+				Endpoint:        b.Method + " " + b.URL,
+				BaselineStatus:  b.Status,
+				CandidateStatus: c.Status,
+				Note:            "same status code across baseline and candidate headers",
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,48 @@
+package recorder
+
+import "testing"
+
+func TestDiffFlagsIdenticalResponseAcrossBundles(t *testing.T) {
+	records := []Record{
+		{Bundle: BundleBaseline, Method: "GET", URL: "https://api.example.com/pets/1", Status: 200, ResponseHash: "abc"},
+		{Bundle: BundleCandidate, Method: "GET", URL: "https://api.example.com/pets/1", Status: 200, ResponseHash: "abc"},
+	}
+	findings := Diff(records)
+	if len(findings) != 1 {
+		t.Fatalf("Diff found %d findings, want 1: %+v", len(findings), findings)
+	}
+	if findings[0].Note == "" {
+		t.Errorf("finding missing a note: %+v", findings[0])
+	}
+}
+
+func TestDiffFlagsSameStatusDifferentHash(t *testing.T) {
+	records := []Record{
+		{Bundle: BundleBaseline, Method: "GET", URL: "https://api.example.com/pets/1", Status: 403, ResponseHash: "abc"},
+		{Bundle: BundleCandidate, Method: "GET", URL: "https://api.example.com/pets/1", Status: 403, ResponseHash: "def"},
+	}
+	findings := Diff(records)
+	if len(findings) != 1 {
+		t.Fatalf("Diff found %d findings, want 1: %+v", len(findings), findings)
+	}
+}
+
+func TestDiffIgnoresUnmatchedBundles(t *testing.T) {
+	records := []Record{
+		{Bundle: BundleBaseline, Method: "GET", URL: "https://api.example.com/pets/1", Status: 200, ResponseHash: "abc"},
+		{Bundle: BundleDefault, Method: "GET", URL: "https://api.example.com/pets/2", Status: 200, ResponseHash: "abc"},
+	}
+	if findings := Diff(records); len(findings) != 0 {
+		t.Errorf("Diff found %d findings for an unmatched baseline, want 0: %+v", len(findings), findings)
+	}
+}
+
+func TestDiffRequiresMatchingStatusOrHash(t *testing.T) {
+	records := []Record{
+		{Bundle: BundleBaseline, Method: "GET", URL: "https://api.example.com/pets/1", Status: 200, ResponseHash: "abc"},
+		{Bundle: BundleCandidate, Method: "GET", URL: "https://api.example.com/pets/1", Status: 401, ResponseHash: "def"},
+	}
+	if findings := Diff(records); len(findings) != 0 {
+		t.Errorf("Diff found %d findings for a genuinely different pair, want 0: %+v", len(findings), findings)
+	}
+}
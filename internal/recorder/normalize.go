@@ -0,0 +1,27 @@
+package recorder
+
+import "regexp"
+
+// These patterns cover the volatile values most likely to appear in an
+// otherwise-uniform response: a timestamp, a UUID-shaped request/trace id,
+// or an epoch timestamp echoed back verbatim.
+var (
+	isoTimestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`)
+	uuidPattern         = regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	epochPattern        = regexp.MustCompile(`\b1[4-9]\d{8,9}\b`)
+	whitespacePattern   = regexp.MustCompile(`\s+`)
+)
+
+// normalizeForHash strips the fields that legitimately vary between two
+// requests that are otherwise identical - an ISO timestamp, a UUID-shaped
+// request id, an epoch timestamp - and collapses whitespace runs before
+// hashing, so clusterByHash groups responses by their
+// structural shape instead of splitting a uniform error/200 page into one
+// cluster per fuzz case just because it echoes a fresh id each time.
+func normalizeForHash(body []byte) []byte {
+	normalized := isoTimestampPattern.ReplaceAll(body, []byte("<ts>"))
+	normalized = uuidPattern.ReplaceAll(normalized, []byte("<uuid>"))
+	normalized = epochPattern.ReplaceAll(normalized, []byte("<epoch>"))
+	normalized = whitespacePattern.ReplaceAll(normalized, []byte(" "))
+	return normalized
+}
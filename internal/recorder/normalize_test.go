@@ -0,0 +1,31 @@
+package recorder
+
+import "testing"
+
+func TestNormalizeForHashStripsVolatileFields(t *testing.T) {
+	a := []byte(`{"id": 1, "requestId": "11111111-1111-1111-1111-111111111111", "ts": "2024-01-02T03:04:05Z"}`)
+	b := []byte(`{"id": 1, "requestId": "22222222-2222-2222-2222-222222222222", "ts": "2024-06-07T08:09:10.123Z"}`)
+
+	na, nb := normalizeForHash(a), normalizeForHash(b)
+	if string(na) != string(nb) {
+		t.Errorf("normalizeForHash didn't converge on volatile fields:\n%s\n%s", na, nb)
+	}
+}
+
+func TestNormalizeForHashStripsEpochAndCollapsesWhitespace(t *testing.T) {
+	a := []byte("{\"seen_at\":  1700000000,\n\n\"status\":\"ok\"}")
+	b := []byte(`{"seen_at": 1712345678, "status":"ok"}`)
+
+	na, nb := normalizeForHash(a), normalizeForHash(b)
+	if string(na) != string(nb) {
+		t.Errorf("normalizeForHash didn't strip epoch/whitespace noise:\n%s\n%s", na, nb)
+	}
+}
+
+func TestNormalizeForHashPreservesRealDifferences(t *testing.T) {
+	a := normalizeForHash([]byte(`{"status":"ok"}`))
+	b := normalizeForHash([]byte(`{"status":"error"}`))
+	if string(a) == string(b) {
+		t.Errorf("normalizeForHash collapsed two genuinely different bodies: %s", a)
+	}
+}
@@ -0,0 +1,87 @@
+// Package recorder captures what actually happened for each fuzzed
+// request — status, latency, response shape — and turns that into a
+// findings report instead of the fire-and-forget status-code printing SPR
+// used to do.
+package recorder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxBodyBytes bounds how much of a response body we ever read into
+// memory; anything past this is truncated before hashing/length-counting.
+const maxBodyBytes = 1 << 20 // 1 MiB
+
+// headersOfInterest are the response headers worth keeping around for
+// triage — auth/rate-limit signals mostly, not the full header set.
+var headersOfInterest = []string{
+	"Content-Type",
+	"WWW-Authenticate",
+	"Set-Cookie",
+	"X-RateLimit-Remaining",
+	"Location",
+}
+
+// Bundle identifies which header set a request was sent with, used by
+// differential mode to pair up a baseline/candidate pair of the same
+// request.
+type Bundle string
+
+const (
+	BundleDefault   Bundle = ""
+	BundleBaseline  Bundle = "baseline"
+	BundleCandidate Bundle = "candidate"
+)
+
+// Record is everything captured about a single fuzzed request/response.
+type Record struct {
+	Bundle            Bundle            `json:"bundle,omitempty"`
+	Method            string            `json:"method"`
+	URL               string            `json:"url"`
+	RequestBody       string            `json:"request_body,omitempty"`
+	ContentType       string            `json:"content_type,omitempty"`
+	Status            int               `json:"status"`
+	LatencyMS         int64             `json:"latency_ms"`
+	ResponseLength    int               `json:"response_length"`
+	ResponseHash      string            `json:"response_hash"`
+	HeadersOfInterest map[string]string `json:"headers,omitempty"`
+	Error             string            `json:"error,omitempty"`
+}
+
+// newRecord reads resp's body (bounded), hashes its normalized form (see
+// normalizeForHash), and assembles a Record.
+// On a transport error (resp == nil) it still records the attempt with
+// Status 0 and the error message, so failed requests show up in the report
+// rather than silently vanishing.
+func newRecord(bundle Bundle, method, url, contentType string, reqBody []byte, resp *http.Response, err error, latency time.Duration) Record {
+	rec := Record{
+		Bundle:      bundle,
+		Method:      method,
+		URL:         url,
+		RequestBody: string(reqBody),
+		ContentType: contentType,
+		LatencyMS:   latency.Milliseconds(),
+	}
+	if err != nil {
+		rec.Error = err.Error()
+		return rec
+	}
+
+	rec.Status = resp.StatusCode
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	rec.ResponseLength = len(body)
+	sum := sha256.Sum256(normalizeForHash(body))
+	rec.ResponseHash = hex.EncodeToString(sum[:])
+
+	rec.HeadersOfInterest = map[string]string{}
+	for _, h := range headersOfInterest {
+		if v := resp.Header.Get(h); v != "" {
+			rec.HeadersOfInterest[h] = v
+		}
+	}
+	return rec
+}
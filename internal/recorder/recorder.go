@@ -0,0 +1,38 @@
+package recorder
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Recorder collects Records from every worker goroutine. It is safe for
+// concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// New returns an empty Recorder.
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// Capture reads resp's body and appends the resulting Record. Callers
+// remain responsible for closing resp.Body; Capture only reads from it.
+func (r *Recorder) Capture(bundle Bundle, method, url, contentType string, reqBody []byte, resp *http.Response, err error, latency time.Duration) Record {
+	rec := newRecord(bundle, method, url, contentType, reqBody, resp, err, latency)
+	r.mu.Lock()
+	r.records = append(r.records, rec)
+	r.mu.Unlock()
+	return rec
+}
+
+// Records returns a copy of every Record captured so far.
+func (r *Recorder) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]Record, len(r.records))
+	copy(out, r.records)
+	return out
+}
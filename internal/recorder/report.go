@@ -0,0 +1,138 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/url"
+	"os"
+	"sort"
+)
+
+// Cluster groups every record at an endpoint that produced the same
+// normalized response hash — a uniform error page (or a uniform 200)
+// hiding behind what looked like N independent fuzz cases, even if each
+// response embeds its own timestamp or UUID-shaped request id.
+type Cluster struct {
+	ResponseHash string `json:"response_hash"`
+	Status       int    `json:"status"`
+	Count        int    `json:"count"`
+}
+
+// Endpoint groups every record made against the same method+path,
+// regardless of which query string or fuzz strategy produced it.
+type Endpoint struct {
+	Method   string    `json:"method"`
+	Path     string    `json:"path"`
+	Records  []Record  `json:"records"`
+	Clusters []Cluster `json:"clusters"`
+}
+
+// Report is the top-level findings document written at the end of a run.
+type Report struct {
+	Endpoints []Endpoint    `json:"endpoints"`
+	Diff      []DiffFinding `json:"diff,omitempty"`
+}
+
+// Report groups every captured record by endpoint and clusters each
+// endpoint's responses by hash. If baseline/candidate bundles are present
+// it also runs the differential analysis and attaches it.
+func (r *Recorder) Report() Report {
+	records := r.Records()
+
+	groups := map[string]*Endpoint{}
+	var order []string
+	for _, rec := range records {
+		path := endpointPath(rec.URL)
+		key := rec.Method + " " + path
+		ep, ok := groups[key]
+		if !ok {
+			ep = &Endpoint{Method: rec.Method, Path: path}
+			groups[key] = ep
+			order = append(order, key)
+		}
+		ep.Records = append(ep.Records, rec)
+	}
+
+	sort.Strings(order)
+	report := Report{}
+	for _, key := range order {
+		ep := groups[key]
+		ep.Clusters = clusterByHash(ep.Records)
+		report.Endpoints = append(report.Endpoints, *ep)
+	}
+
+	report.Diff = Diff(records)
+	return report
+}
+
+func endpointPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Path
+}
+
+func clusterByHash(records []Record) []Cluster {
+	byHash := map[string]*Cluster{}
+	var order []string
+	for _, rec := range records {
+		c, ok := byHash[rec.ResponseHash]
+		if !ok {
+			c = &Cluster{ResponseHash: rec.ResponseHash, Status: rec.Status}
+			byHash[rec.ResponseHash] = c
+			order = append(order, rec.ResponseHash)
+		}
+		c.Count++
+	}
+	clusters := make([]Cluster, 0, len(order))
+	for _, hash := range order {
+		clusters = append(clusters, *byHash[hash])
+	}
+	return clusters
+}
+
+// WriteJSON marshals the report to path as indented JSON.
+func (rep Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling report: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>SPR report</title></head>
+<body>
+<h1>SPR findings report</h1>
+{{if .Diff}}
+<h2>Differential findings</h2>
+<table border="1" cellpadding="4">
+<tr><th>Endpoint</th><th>Baseline status</th><th>Candidate status</th><th>Note</th></tr>
+{{range .Diff}}<tr><td>{{.Endpoint}}</td><td>{{.BaselineStatus}}</td><td>{{.CandidateStatus}}</td><td>{{.Note}}</td></tr>
+{{end}}
+</table>
+{{end}}
+<h2>Endpoints</h2>
+{{range .Endpoints}}
+<h3>{{.Method}} {{.Path}}</h3>
+<p>{{len .Records}} requests, {{len .Clusters}} distinct response(s)</p>
+<table border="1" cellpadding="4">
+<tr><th>Status</th><th>Count</th><th>Response hash</th></tr>
+{{range .Clusters}}<tr><td>{{.Status}}</td><td>{{.Count}}</td><td>{{.ResponseHash}}</td></tr>
+{{end}}
+</table>
+{{end}}
+</body></html>
+`))
+
+// WriteHTML renders the report as a single static HTML page to path.
+func (rep Report) WriteHTML(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating report file: %w", err)
+	}
+	defer f.Close()
+	return htmlTemplate.Execute(f, rep)
+}
@@ -0,0 +1,90 @@
+package stateful
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/vrechson/SPR/internal/openapi"
+)
+
+// CaptureResponse parses a successful JSON response body and recursively
+// walks it, saving every field that looks like an identifier - at any
+// depth, not just the top level, so shapes like {"data": {"id": ...}}
+// still contribute - both under heuristic names ("<resource>.<field>", the
+// bare field name, "<resource><Field>") and under its true JSON Pointer
+// (RFC 6901) relative to the resource, e.g. "pets/data/id". It also honors
+// any field whose schema carries an explicit x-spr-capture key. resource is
+// the heuristic resource name derived from the request path (see
+// ResourceName).
+func (s *Store) CaptureResponse(resource string, body []byte, schema *openapi.Schema) {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return
+	}
+	captureNode(s, resource, "", decoded, schema)
+}
+
+// captureNode recurses into a decoded JSON value, tracking pointer as the
+// RFC 6901 JSON Pointer from the response root to node.
+func captureNode(s *Store, resource, pointer string, node interface{}, schema *openapi.Schema) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			childPointer := pointer + "/" + escapePointerToken(key)
+
+			var childSchema *openapi.Schema
+			if schema != nil && schema.Properties != nil {
+				childSchema = schema.Properties[key]
+			}
+
+			if isIDLike(key) {
+				s.Set(resource+"."+key, value)
+				s.Set(key, value)
+				s.Set(resource+strings.ToUpper(key[:1])+key[1:], value)
+				s.Set(resource+childPointer, value)
+			}
+			if childSchema != nil && childSchema.XSPRCapture != "" {
+				s.Set(childSchema.XSPRCapture, value)
+			}
+
+			captureNode(s, resource, childPointer, value, childSchema)
+		}
+
+	case []interface{}:
+		var itemSchema *openapi.Schema
+		if schema != nil {
+			itemSchema = schema.Items
+		}
+		for i, item := range v {
+			captureNode(s, resource, fmt.Sprintf("%s/%d", pointer, i), item, itemSchema)
+		}
+	}
+}
+
+// escapePointerToken escapes a single JSON Pointer reference token per
+// RFC 6901: "~" must be encoded first, then "/".
+func escapePointerToken(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	return strings.ReplaceAll(token, "/", "~1")
+}
+
+// isIDLike is the heuristic SPR uses to decide a response field is worth
+// remembering: a bare "id"/"uuid", or any field name ending in "id" in any
+// letter case ("petId", "pet_id", "PETID"; also names such as "valid").
+func isIDLike(field string) bool {
+	lower := strings.ToLower(field)
+	return lower == "id" || lower == "uuid" || strings.HasSuffix(lower, "id")
+}
+
+// ResourceName derives a heuristic resource name from a path template by
+// taking its first non-parameter segment, e.g. "/pets/{petId}/owner" ->
+// "pets".
+func ResourceName(path string) string {
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" && !strings.HasPrefix(segment, "{") {
+			return segment
+		}
+	}
+	return path
+}
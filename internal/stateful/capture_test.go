@@ -0,0 +1,56 @@
+package stateful
+
+import "testing"
+
+func TestCaptureResponseTopLevelID(t *testing.T) {
+	s := NewStore()
+	s.CaptureResponse("pets", []byte(`{"id": 42, "name": "fido"}`), nil)
+
+	if v, ok := s.Get("pets.id"); !ok || v != float64(42) {
+		t.Errorf("pets.id = %v, %v, want 42, true", v, ok)
+	}
+	if v, ok := s.Get("id"); !ok || v != float64(42) {
+		t.Errorf("id = %v, %v, want 42, true", v, ok)
+	}
+}
+
+func TestCaptureResponseNestedObject(t *testing.T) {
+	s := NewStore()
+	s.CaptureResponse("pets", []byte(`{"data": {"id": 7}}`), nil)
+
+	if v, ok := s.Get("pets/data/id"); !ok || v != float64(7) {
+		t.Errorf("pets/data/id = %v, %v, want 7, true", v, ok)
+	}
+	if v, ok := s.Get("pets.id"); !ok || v != float64(7) {
+		t.Errorf("nested id should still populate the heuristic pets.id key: got %v, %v", v, ok)
+	}
+}
+
+func TestCaptureResponseNestedArray(t *testing.T) {
+	s := NewStore()
+	s.CaptureResponse("pets", []byte(`{"items": [{"id": 1}, {"id": 2}]}`), nil)
+
+	if v, ok := s.Get("pets/items/1/id"); !ok || v != float64(2) {
+		t.Errorf("pets/items/1/id = %v, %v, want 2, true", v, ok)
+	}
+}
+
+func TestCaptureResponseEscapesPointerTokens(t *testing.T) {
+	if got := escapePointerToken("a/b~c"); got != "a~1b~0c" {
+		t.Errorf("escapePointerToken(%q) = %q, want %q", "a/b~c", got, "a~1b~0c")
+	}
+}
+
+func TestCaptureResponseIgnoresInvalidJSON(t *testing.T) {
+	s := NewStore()
+	s.CaptureResponse("pets", []byte(`not json`), nil)
+	if _, ok := s.Get("id"); ok {
+		t.Errorf("invalid JSON body shouldn't populate the store")
+	}
+}
+
+func TestResourceNameTakesFirstNonParameterSegment(t *testing.T) {
+	if got := ResourceName("/pets/{petId}/owner"); got != "pets" {
+		t.Errorf("ResourceName = %q, want %q", got, "pets")
+	}
+}
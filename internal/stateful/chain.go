@@ -0,0 +1,92 @@
+package stateful
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vrechson/SPR/internal/openapi"
+)
+
+// ChainOverride is a parsed -chain flag: inject the value captured under
+// Key into the named Param whenever Path is fuzzed, overriding whatever the
+// heuristic matcher in Overrides would have picked.
+type ChainOverride struct {
+	Key   string
+	Path  string
+	Param string
+}
+
+// ParseChain parses the "-chain resource.id->/pets/{petId}" DSL: a capture
+// key, a literal "->", and the path template whose trailing {param}
+// receives that value.
+func ParseChain(spec string) (ChainOverride, error) {
+	key, path, ok := strings.Cut(spec, "->")
+	if !ok {
+		return ChainOverride{}, fmt.Errorf("invalid -chain %q: expected KEY->PATH{param}", spec)
+	}
+	key = strings.TrimSpace(key)
+	path = strings.TrimSpace(path)
+
+	start := strings.LastIndex(path, "{")
+	end := strings.LastIndex(path, "}")
+	if start == -1 || end != len(path)-1 || end < start {
+		return ChainOverride{}, fmt.Errorf("invalid -chain %q: path must end in a {param}", spec)
+	}
+	return ChainOverride{Key: key, Path: path, Param: path[start+1 : end]}, nil
+}
+
+// Overrides computes the param-name -> string value map that should be
+// merged into an operation's param overrides before it's fuzzed: one entry
+// per parameter whose value can be inferred from an explicit ChainOverride,
+// an x-spr-inject hint, or the bare heuristic id/<resource>Id name match
+// against something already captured in the store.
+func Overrides(store *Store, op Operation, chains []ChainOverride) map[string]string {
+	overrides := map[string]string{}
+	allParams := append(append([]*openapi.Parameter{}, op.PathItem.Parameters...), op.Operation.Parameters...)
+
+	for _, param := range allParams {
+		if value, ok := resolveChain(store, op.Path, param.Name, chains); ok {
+			overrides[param.Name] = value
+			continue
+		}
+		if param.XSPRInject != "" {
+			if value, ok := store.Get(param.XSPRInject); ok {
+				overrides[param.Name] = fmt.Sprint(value)
+				continue
+			}
+		}
+		if value, ok := heuristicMatch(store, op.Resource, param.Name); ok {
+			overrides[param.Name] = value
+		}
+	}
+	return overrides
+}
+
+func resolveChain(store *Store, path, paramName string, chains []ChainOverride) (string, bool) {
+	for _, c := range chains {
+		if c.Path == path && c.Param == paramName {
+			if value, ok := store.Get(c.Key); ok {
+				return fmt.Sprint(value), true
+			}
+		}
+	}
+	return "", false
+}
+
+// heuristicMatch tries, in order: "id" captured for this resource,
+// "<resource>Id", and finally a bare match on the parameter's own name.
+func heuristicMatch(store *Store, resource, paramName string) (string, bool) {
+	if !isIDLike(paramName) {
+		return "", false
+	}
+	if value, ok := store.Get(resource + ".id"); ok {
+		return fmt.Sprint(value), true
+	}
+	if value, ok := store.Get(resource + "Id"); ok {
+		return fmt.Sprint(value), true
+	}
+	if value, ok := store.Get(paramName); ok {
+		return fmt.Sprint(value), true
+	}
+	return "", false
+}
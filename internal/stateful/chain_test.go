@@ -0,0 +1,103 @@
+package stateful
+
+import (
+	"testing"
+
+	"github.com/vrechson/SPR/internal/openapi"
+)
+
+func TestParseChainValidSpec(t *testing.T) {
+	c, err := ParseChain("pets.id->/pets/{petId}")
+	if err != nil {
+		t.Fatalf("ParseChain: %v", err)
+	}
+	if c.Key != "pets.id" || c.Path != "/pets/{petId}" || c.Param != "petId" {
+		t.Errorf("ParseChain = %+v, want Key=pets.id Path=/pets/{petId} Param=petId", c)
+	}
+}
+
+func TestParseChainRejectsMissingArrow(t *testing.T) {
+	if _, err := ParseChain("pets.id/pets/{petId}"); err == nil {
+		t.Error("ParseChain should reject a spec without \"->\"")
+	}
+}
+
+func TestParseChainRejectsMissingParam(t *testing.T) {
+	if _, err := ParseChain("pets.id->/pets"); err == nil {
+		t.Error("ParseChain should reject a path with no trailing {param}")
+	}
+}
+
+func TestOverridesPrefersExplicitChainOverHeuristic(t *testing.T) {
+	store := NewStore()
+	store.Set("pets.id", 1)
+	store.Set("otherId", 99)
+
+	op := Operation{
+		Path:     "/pets/{petId}",
+		Resource: "pets",
+		Operation: &openapi.Operation{
+			Parameters: []*openapi.Parameter{{Name: "petId"}},
+		},
+	}
+	chains := []ChainOverride{{Key: "otherId", Path: "/pets/{petId}", Param: "petId"}}
+
+	overrides := Overrides(store, op, chains)
+	if overrides["petId"] != "99" {
+		t.Errorf("overrides[petId] = %q, want %q (explicit -chain should win)", overrides["petId"], "99")
+	}
+}
+
+func TestOverridesFallsBackToHeuristicMatch(t *testing.T) {
+	store := NewStore()
+	store.Set("pets.id", 5)
+
+	op := Operation{
+		Path:     "/pets/{petId}",
+		Resource: "pets",
+		Operation: &openapi.Operation{
+			Parameters: []*openapi.Parameter{{Name: "petId"}},
+		},
+	}
+
+	overrides := Overrides(store, op, nil)
+	if overrides["petId"] != "5" {
+		t.Errorf("overrides[petId] = %q, want %q (heuristic resource.id match)", overrides["petId"], "5")
+	}
+}
+
+func TestOverridesHonorsXSPRInject(t *testing.T) {
+	store := NewStore()
+	store.Set("customKey", "abc")
+
+	op := Operation{
+		Path:     "/pets/{petId}",
+		Resource: "pets",
+		Operation: &openapi.Operation{
+			Parameters: []*openapi.Parameter{{Name: "petId", XSPRInject: "customKey"}},
+		},
+	}
+
+	overrides := Overrides(store, op, nil)
+	if overrides["petId"] != "abc" {
+		t.Errorf("overrides[petId] = %q, want %q (x-spr-inject hint)", overrides["petId"], "abc")
+	}
+}
+
+func TestOverridesSkipsNonIDLikeParams(t *testing.T) {
+	store := NewStore()
+	store.Set("pets.id", 5)
+
+	op := Operation{
+		Path:     "/pets",
+		Resource: "pets",
+		Operation: &openapi.Operation{
+			Parameters: []*openapi.Parameter{{Name: "limit"}},
+		},
+	}
+
+	overrides := Overrides(store, op, nil)
+	if _, ok := overrides["limit"]; ok {
+		t.Errorf("overrides shouldn't guess a value for a non-id-like param: %+v", overrides)
+	}
+}
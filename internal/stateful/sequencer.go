@@ -0,0 +1,88 @@
+package stateful
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vrechson/SPR/internal/openapi"
+)
+
+// Operation is a single path+method pulled out of a Document's Paths map,
+// along with the resource name it was sequenced under.
+type Operation struct {
+	Path      string
+	Method    string
+	Resource  string
+	PathItem  openapi.PathItem
+	Operation *openapi.Operation
+}
+
+// Sequence topologically orders every operation so that a resource's
+// collection-level POST/PUT (which creates an item) runs before any
+// item-level GET/PUT/DELETE on that same resource, which in turn runs
+// before the item is deleted. Ties are broken by resource, path, then
+// method name so the order is deterministic across runs.
+func Sequence(paths map[string]openapi.PathItem, methodsMap map[string]bool) []Operation {
+	var ops []Operation
+	for path, pathItem := range paths {
+		for method, op := range pathItem.Operations() {
+			if !methodsMap[method] {
+				continue
+			}
+			ops = append(ops, Operation{
+				Path:      path,
+				Method:    method,
+				Resource:  ResourceName(path),
+				PathItem:  pathItem,
+				Operation: op,
+			})
+		}
+	}
+
+	sort.SliceStable(ops, func(i, j int) bool {
+		ri, rj := rank(ops[i]), rank(ops[j])
+		if ri != rj {
+			return ri < rj
+		}
+		if ops[i].Resource != ops[j].Resource {
+			return ops[i].Resource < ops[j].Resource
+		}
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return ops[i].Method < ops[j].Method
+	})
+	return ops
+}
+
+// isItemPath reports whether a path's final segment is a {parameter},
+// i.e. it addresses a single item rather than a collection.
+func isItemPath(path string) bool {
+	segments := strings.Split(strings.TrimRight(path, "/"), "/")
+	if len(segments) == 0 {
+		return false
+	}
+	last := segments[len(segments)-1]
+	return strings.HasPrefix(last, "{") && strings.HasSuffix(last, "}")
+}
+
+// rank orders operations so collection-level creates run first, then
+// item-level updates, then reads, then deletes; any other method (HEAD,
+// OPTIONS) runs after the deletes.
+func rank(op Operation) int {
+	item := isItemPath(op.Path)
+	switch {
+	case (op.Method == "POST" || op.Method == "PUT") && !item:
+		return 0
+	case op.Method == "PUT" && item:
+		return 1
+	case op.Method == "PATCH":
+		return 1
+	case op.Method == "GET":
+		return 2
+	case op.Method == "DELETE":
+		return 3
+	default:
+		return 4
+	}
+}
@@ -0,0 +1,37 @@
+// Package stateful orders operations into CRUD-shaped sequences and threads
+// captured response values into later requests so a POST's generated id
+// actually shows up in the GET/DELETE that follows it.
+package stateful
+
+import "sync"
+
+// Store is a name-indexed table of values captured from prior responses.
+// A single capture is recorded under several keys so later lookups can use
+// whichever is most convenient: a bare heuristic name like "id" / "petId",
+// "<resource>.<field>", and the value's true JSON Pointer (RFC 6901)
+// relative to the resource, e.g. "pets/data/id". Safe for concurrent use,
+// though -stateful mode runs sequentially.
+type Store struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{values: map[string]interface{}{}}
+}
+
+// Set records value under key, overwriting whatever was there.
+func (s *Store) Set(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+// Get looks up a previously captured value.
+func (s *Store) Get(key string) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	return v, ok
+}
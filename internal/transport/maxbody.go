@@ -0,0 +1,40 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+)
+
+// maxBodyTransport caps how many bytes of a request's outgoing body and a
+// response's incoming body get sent/read, so a malicious or misbehaving
+// target can't make a fuzz run balloon in memory.
+type maxBodyTransport struct {
+	next     http.RoundTripper
+	maxBytes int64
+}
+
+func newMaxBodyTransport(next http.RoundTripper, maxBytes int64) http.RoundTripper {
+	if maxBytes <= 0 {
+		return next
+	}
+	return &maxBodyTransport{next: next, maxBytes: maxBytes}
+}
+
+func (t *maxBodyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body = io.NopCloser(io.LimitReader(req.Body, t.maxBytes))
+		// ContentLength must agree with what the capped body will actually
+		// yield, or the transport rejects the response as a broken
+		// connection; -1 tells it to fall back to chunked encoding.
+		if req.ContentLength < 0 || req.ContentLength > t.maxBytes {
+			req.ContentLength = -1
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	resp.Body = io.NopCloser(io.LimitReader(resp.Body, t.maxBytes))
+	return resp, nil
+}
@@ -0,0 +1,56 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxBodyTransportCapsResponseBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer srv.Close()
+
+	rt := newMaxBodyTransport(http.DefaultTransport, 10)
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if len(body) != 10 {
+		t.Errorf("response body length = %d, want 10", len(body))
+	}
+}
+
+func TestMaxBodyTransportCapsRequestBody(t *testing.T) {
+	var gotLen int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotLen = len(body)
+	}))
+	defer srv.Close()
+
+	rt := newMaxBodyTransport(http.DefaultTransport, 5)
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(strings.Repeat("b", 50)))
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotLen != 5 {
+		t.Errorf("server received %d request body bytes, want 5", gotLen)
+	}
+}
+
+func TestMaxBodyTransportDisabledWhenZero(t *testing.T) {
+	if rt := newMaxBodyTransport(http.DefaultTransport, 0); rt != http.RoundTripper(http.DefaultTransport) {
+		t.Errorf("newMaxBodyTransport(0) should return next unchanged, got %T", rt)
+	}
+}
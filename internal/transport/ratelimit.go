@@ -0,0 +1,49 @@
+package transport
+
+import (
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedTransport throttles outgoing requests to at most rps per
+// second (with burst allowance) per destination host, so a large fuzz run
+// doesn't trip the target's own rate limiting before SPR can observe it.
+type rateLimitedTransport struct {
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	rps      float64
+	burst    int
+}
+
+func newRateLimitedTransport(next http.RoundTripper, rps float64, burst int) http.RoundTripper {
+	if rps <= 0 {
+		return next
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimitedTransport{next: next, limiters: map[string]*rate.Limiter{}, rps: rps, burst: burst}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := t.limiterFor(req.URL.Host)
+	if err := limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+func (t *rateLimitedTransport) limiterFor(host string) *rate.Limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	limiter, ok := t.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(t.rps), t.burst)
+		t.limiters[host] = limiter
+	}
+	return limiter
+}
@@ -0,0 +1,49 @@
+package transport
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vrechson/SPR/internal/recorder"
+)
+
+// StatusFilter decides whether a previously captured record should be
+// replayed, e.g. "only 500s" or "only 2xx".
+type StatusFilter func(status int) bool
+
+// Replay re-issues every record in report whose status passes filter,
+// against client, with headerMap applied. It's meant to let a user iterate
+// on a subset of findings (-replay prior-report.json -replay-status 500)
+// without re-running the whole fuzz matrix.
+func Replay(client *http.Client, report recorder.Report, filter StatusFilter, headerMap map[string]string) []recorder.Record {
+	rec := recorder.New()
+	for _, endpoint := range report.Endpoints {
+		for _, prior := range endpoint.Records {
+			if !filter(prior.Status) {
+				continue
+			}
+
+			req, err := http.NewRequest(prior.Method, prior.URL, strings.NewReader(prior.RequestBody))
+			if err != nil {
+				rec.Capture(prior.Bundle, prior.Method, prior.URL, prior.ContentType, []byte(prior.RequestBody), nil, err, 0)
+				continue
+			}
+			if prior.ContentType != "" {
+				req.Header.Set("Content-Type", prior.ContentType)
+			}
+			for key, value := range headerMap {
+				req.Header.Set(key, value)
+			}
+
+			start := time.Now()
+			resp, err := client.Do(req)
+			latency := time.Since(start)
+			rec.Capture(prior.Bundle, prior.Method, prior.URL, prior.ContentType, []byte(prior.RequestBody), resp, err, latency)
+			if resp != nil {
+				resp.Body.Close()
+			}
+		}
+	}
+	return rec.Records()
+}
@@ -0,0 +1,37 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vrechson/SPR/internal/recorder"
+)
+
+func TestReplayReissuesStoredContentType(t *testing.T) {
+	var gotContentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+	}))
+	defer srv.Close()
+
+	report := recorder.Report{
+		Endpoints: []recorder.Endpoint{{
+			Method: "POST",
+			Path:   "/pets",
+			Records: []recorder.Record{{
+				Method:      "POST",
+				URL:         srv.URL,
+				Status:      200,
+				ContentType: "application/x-www-form-urlencoded",
+				RequestBody: "name=fido",
+			}},
+		}},
+	}
+
+	Replay(srv.Client(), report, func(int) bool { return true }, nil)
+
+	if gotContentType != "application/x-www-form-urlencoded" {
+		t.Errorf("replayed Content-Type = %q, want %q", gotContentType, "application/x-www-form-urlencoded")
+	}
+}
@@ -0,0 +1,80 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryTransport retries a 429 or 5xx response with exponential backoff,
+// honoring a Retry-After header (seconds or HTTP-date) when present.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int) http.RoundTripper {
+	if maxRetries <= 0 {
+		return next
+	}
+	return &retryTransport{next: next, maxRetries: maxRetries}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+
+	backoff := 250 * time.Millisecond
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; attempt <= t.maxRetries; attempt++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err = t.next.RoundTrip(req)
+		if err != nil || !shouldRetry(resp) || attempt == t.maxRetries {
+			return resp, err
+		}
+
+		wait := retryAfter(resp.Header.Get("Retry-After"))
+		if wait == 0 {
+			wait = backoff
+			backoff *= 2
+		}
+		resp.Body.Close()
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	return resp, err
+}
+
+func shouldRetry(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// retryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP-date. Returns 0 if the header is absent or invalid,
+// signaling "use the exponential backoff instead".
+func retryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
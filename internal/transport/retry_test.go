@@ -0,0 +1,49 @@
+package transport
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterParsesSeconds(t *testing.T) {
+	if got := retryAfter("5"); got != 5*time.Second {
+		t.Errorf("retryAfter(\"5\") = %v, want 5s", got)
+	}
+}
+
+func TestRetryAfterParsesHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	got := retryAfter(future)
+	if got <= 0 || got > 10*time.Second {
+		t.Errorf("retryAfter(%q) = %v, want a positive duration close to 10s", future, got)
+	}
+}
+
+func TestRetryAfterReturnsZeroWhenAbsentOrInvalid(t *testing.T) {
+	if got := retryAfter(""); got != 0 {
+		t.Errorf("retryAfter(\"\") = %v, want 0", got)
+	}
+	if got := retryAfter("not-a-valid-header"); got != 0 {
+		t.Errorf("retryAfter(garbage) = %v, want 0", got)
+	}
+}
+
+func TestShouldRetryOnRateLimitAndServerErrors(t *testing.T) {
+	cases := []struct {
+		status int
+		want   bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusNotFound, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+	}
+	for _, c := range cases {
+		resp := &http.Response{StatusCode: c.status}
+		if got := shouldRetry(resp); got != c.want {
+			t.Errorf("shouldRetry(status=%d) = %v, want %v", c.status, got, c.want)
+		}
+	}
+}
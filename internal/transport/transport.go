@@ -0,0 +1,94 @@
+// Package transport builds the http.Client SPR sends fuzzed requests
+// through: proxying (HTTP or SOCKS5/Tor), optional mTLS, HTTP/2, and a
+// rate-limit + retry RoundTripper chain wrapped around the base transport.
+package transport
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/quic-go/quic-go/http3"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/proxy"
+)
+
+// Config holds every -proxy/-tor/-client-cert/-rps-style knob.
+type Config struct {
+	// ProxyURL is an http:// or https:// forwarding proxy, e.g. Burp/ZAP.
+	ProxyURL string
+	// SOCKS5Addr is a "host:port" SOCKS5 proxy, as given to -tor (e.g.
+	// 127.0.0.1:9050 for Tor). Takes precedence over ProxyURL when set.
+	SOCKS5Addr string
+	// InsecureSkipVerify disables TLS certificate verification, matching
+	// SPR's historical behavior against self-signed proxy certs.
+	InsecureSkipVerify bool
+	// ClientCertPath/ClientKeyPath enable mutual TLS.
+	ClientCertPath string
+	ClientKeyPath  string
+	// HTTP2 enables HTTP/2 over the transport (h2c is out of scope; this
+	// only affects TLS connections' negotiated protocol).
+	HTTP2 bool
+	// HTTP3 swaps the base RoundTripper for a QUIC/HTTP3 one. Mutually
+	// exclusive with HTTP2 and with the proxy settings above, which QUIC
+	// doesn't go through.
+	HTTP3 bool
+	// RPS and Burst configure the per-host token-bucket rate limiter.
+	// RPS <= 0 disables rate limiting entirely.
+	RPS   float64
+	Burst int
+	// MaxRetries is how many additional attempts a 429/5xx response gets,
+	// with exponential backoff honoring Retry-After. 0 disables retries.
+	MaxRetries int
+	// MaxBodyBytes caps how much of a request/response body the transport
+	// will buffer; 0 means no cap.
+	MaxBodyBytes int64
+}
+
+// New builds an *http.Client wired up per cfg.
+func New(cfg Config) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+	if cfg.ClientCertPath != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertPath, cfg.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading client cert/key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	var rt http.RoundTripper
+	if cfg.HTTP3 {
+		rt = &http3.RoundTripper{TLSClientConfig: tlsConfig}
+	} else {
+		base := &http.Transport{TLSClientConfig: tlsConfig}
+
+		switch {
+		case cfg.SOCKS5Addr != "":
+			dialer, err := proxy.SOCKS5("tcp", cfg.SOCKS5Addr, nil, proxy.Direct)
+			if err != nil {
+				return nil, fmt.Errorf("configuring SOCKS5 proxy: %w", err)
+			}
+			base.Dial = dialer.Dial
+		case cfg.ProxyURL != "":
+			proxyURL, err := url.Parse(cfg.ProxyURL)
+			if err != nil {
+				return nil, fmt.Errorf("parsing proxy URL: %w", err)
+			}
+			base.Proxy = http.ProxyURL(proxyURL)
+		}
+
+		if cfg.HTTP2 {
+			if err := http2.ConfigureTransport(base); err != nil {
+				return nil, fmt.Errorf("configuring HTTP/2: %w", err)
+			}
+		}
+		rt = base
+	}
+
+	rt = newRateLimitedTransport(rt, cfg.RPS, cfg.Burst)
+	rt = newRetryTransport(rt, cfg.MaxRetries)
+	rt = newMaxBodyTransport(rt, cfg.MaxBodyBytes)
+
+	return &http.Client{Transport: rt}, nil
+}